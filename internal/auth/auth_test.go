@@ -0,0 +1,99 @@
+package auth
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/argon2"
+)
+
+func TestPasswordRoundTrip(t *testing.T) {
+	for _, password := range []string{"correct horse battery staple", "", "pässwörd 🔑"} {
+		hash, err := HashPassword(password)
+		if err != nil {
+			t.Fatalf("HashPassword returned error: %v", err)
+		}
+		if !strings.HasPrefix(hash, "$argon2id$v=19$") {
+			t.Errorf("got hash %q, want an argon2id PHC string", hash)
+		}
+
+		if err := CheckPassword(hash, password); err != nil {
+			t.Errorf("CheckPassword(%q) with its own hash returned error: %v", password, err)
+		}
+		if err := CheckPassword(hash, password+"x"); !errors.Is(err, ErrMismatchedPassword) {
+			t.Errorf("CheckPassword with the wrong password returned %v, want ErrMismatchedPassword", err)
+		}
+	}
+}
+
+func TestHashPasswordIsSalted(t *testing.T) {
+	first, err := HashPassword("secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := HashPassword("secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first == second {
+		t.Error("hashing the same password twice gave the same hash")
+	}
+}
+
+func TestCheckPasswordUsesStoredParameters(t *testing.T) {
+	// A hash made with cheaper parameters than the current ones still verifies
+	salt := []byte("0123456789abcdef")
+	key := argon2.IDKey([]byte("secret"), salt, 1, 8*1024, 1, 16)
+	hash := fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s", argon2.Version, 8*1024, 1, 1,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key))
+
+	if err := CheckPassword(hash, "secret"); err != nil {
+		t.Errorf("CheckPassword returned error: %v", err)
+	}
+	if err := CheckPassword(hash, "Secret"); !errors.Is(err, ErrMismatchedPassword) {
+		t.Errorf("CheckPassword with the wrong password returned %v, want ErrMismatchedPassword", err)
+	}
+}
+
+func TestCheckPasswordInvalidHash(t *testing.T) {
+	for _, hash := range []string{
+		"",
+		"secret",
+		"$2a$10$abcdefghijklmnopqrstuv",
+		"$argon2i$v=19$m=65536,t=3,p=4$c2FsdA$a2V5",
+		"$argon2id$v=16$m=65536,t=3,p=4$c2FsdA$a2V5",
+		"$argon2id$v=19$m=lots,t=3,p=4$c2FsdA$a2V5",
+		"$argon2id$v=19$m=65536,t=3,p=4$not base64!$a2V5",
+		"$argon2id$v=19$m=65536,t=3,p=4$c2FsdA$not base64!",
+	} {
+		err := CheckPassword(hash, "secret")
+		if err == nil || errors.Is(err, ErrMismatchedPassword) {
+			t.Errorf("CheckPassword(%q) returned %v, want an invalid hash error", hash, err)
+		}
+	}
+}
+
+func TestSessionTokens(t *testing.T) {
+	token, err := NewSessionToken()
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := NewSessionToken()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(token) != 2*tokenLen || token == other {
+		t.Errorf("got tokens %q and %q, want two different %d character tokens", token, other, 2*tokenLen)
+	}
+
+	// Tokens are looked up by their hash, so it must be stable
+	if HashToken(token) != HashToken(token) || HashToken(token) == HashToken(other) {
+		t.Error("HashToken is not a stable, distinct hash of the token")
+	}
+	if hash := HashToken(token); len(hash) != 64 || strings.Contains(hash, token) {
+		t.Errorf("got token hash %q, want 64 hex characters", hash)
+	}
+}
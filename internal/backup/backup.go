@@ -0,0 +1,305 @@
+package backup
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Format identifies gator backup archives
+const Format = "gator-backup"
+
+// Version is the archive format version written by this build. Version 1 archives
+// hold users, feeds, follows and posts, version 2 adds per-user state.
+const Version = 2
+
+// Record types, in the order they are written so that references always point backwards
+const (
+	TypeUser          = "user"
+	TypeFeed          = "feed"
+	TypeFeedFollow    = "feed_follow"
+	TypeFeedFollowTag = "feed_follow_tag"
+	TypePost          = "post"
+	TypePostTombstone = "post_tombstone"
+	TypeRule          = "rule"
+	TypePostRead      = "post_read"
+	TypePostStar      = "post_star"
+	TypePostHide      = "post_hide"
+	TypePostTag       = "post_tag"
+)
+
+// Types lists every record type in the order they are written
+var Types = []string{
+	TypeUser, TypeFeed, TypeFeedFollow, TypeFeedFollowTag, TypePost, TypePostTombstone,
+	TypeRule, TypePostRead, TypePostStar, TypePostHide, TypePostTag,
+}
+
+// Header is the first line of an archive
+type Header struct {
+	Format    string    `json:"format"`
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Record is a single line of an archive after the header
+type Record struct {
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+// User is a user account
+type User struct {
+	ID           uuid.UUID `json:"id"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+	Name         string    `json:"name"`
+	Role         string    `json:"role"`
+	Timezone     *string   `json:"timezone,omitempty"`
+	PasswordHash *string   `json:"password_hash,omitempty"`
+}
+
+// Feed is a feed along with its settings
+type Feed struct {
+	ID                  uuid.UUID  `json:"id"`
+	CreatedAt           time.Time  `json:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at"`
+	Name                string     `json:"name"`
+	URL                 string     `json:"url"`
+	UserID              uuid.UUID  `json:"user_id"`
+	LastFetchedAt       *time.Time `json:"last_fetched_at,omitempty"`
+	Title               *string    `json:"title,omitempty"`
+	Description         *string    `json:"description,omitempty"`
+	Link                *string    `json:"link,omitempty"`
+	Language            *string    `json:"language,omitempty"`
+	ImageURL            *string    `json:"image_url,omitempty"`
+	Paused              bool       `json:"paused"`
+	URLKey              *string    `json:"url_key,omitempty"`
+	ContentHash         *string    `json:"content_hash,omitempty"`
+	RetentionMaxAgeDays *int32     `json:"retention_max_age_days,omitempty"`
+	RetentionMaxPosts   *int32     `json:"retention_max_posts,omitempty"`
+}
+
+// FeedFollow is a user following a feed, with their settings for it
+type FeedFollow struct {
+	ID          uuid.UUID `json:"id"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	UserID      uuid.UUID `json:"user_id"`
+	FeedID      uuid.UUID `json:"feed_id"`
+	CustomTitle *string   `json:"custom_title,omitempty"`
+	Muted       bool      `json:"muted"`
+	Priority    int32     `json:"priority"`
+	Notify      bool      `json:"notify"`
+}
+
+// Post is a fetched post
+type Post struct {
+	ID          uuid.UUID  `json:"id"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	Title       string     `json:"title"`
+	URL         string     `json:"url"`
+	Description *string    `json:"description,omitempty"`
+	PublishedAt *time.Time `json:"published_at,omitempty"`
+	FeedID      uuid.UUID  `json:"feed_id"`
+	Content     *string    `json:"content,omitempty"`
+	Author      *string    `json:"author,omitempty"`
+	Categories  *string    `json:"categories,omitempty"`
+	GUID        *string    `json:"guid,omitempty"`
+}
+
+// FeedFollowTag is a tag, or folder, on a feed follow
+type FeedFollowTag struct {
+	FeedFollowID uuid.UUID `json:"feed_follow_id"`
+	Tag          string    `json:"tag"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// PostTombstone remembers a pruned item so it is not fetched again
+type PostTombstone struct {
+	FeedID   uuid.UUID `json:"feed_id"`
+	GUID     string    `json:"guid"`
+	PrunedAt time.Time `json:"pruned_at"`
+}
+
+// Rule is a user's filter rule
+type Rule struct {
+	ID        uuid.UUID `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	UserID    uuid.UUID `json:"user_id"`
+	Field     string    `json:"field"`
+	MatchType string    `json:"match_type"`
+	Pattern   string    `json:"pattern"`
+	Action    string    `json:"action"`
+	Tag       *string   `json:"tag,omitempty"`
+}
+
+// PostRead marks a post as read by a user
+type PostRead struct {
+	UserID uuid.UUID `json:"user_id"`
+	PostID uuid.UUID `json:"post_id"`
+	ReadAt time.Time `json:"read_at"`
+}
+
+// PostStar is a starred post. It keeps a copy of the post, so it outlives it.
+type PostStar struct {
+	ID          uuid.UUID  `json:"id"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	UserID      uuid.UUID  `json:"user_id"`
+	PostID      *uuid.UUID `json:"post_id,omitempty"`
+	PostURL     string     `json:"post_url"`
+	Title       string     `json:"title"`
+	Description *string    `json:"description,omitempty"`
+	PublishedAt *time.Time `json:"published_at,omitempty"`
+	FeedName    string     `json:"feed_name"`
+	Note        *string    `json:"note,omitempty"`
+}
+
+// PostHide is a post hidden from a user by one of their rules
+type PostHide struct {
+	UserID   uuid.UUID `json:"user_id"`
+	PostID   uuid.UUID `json:"post_id"`
+	RuleID   uuid.UUID `json:"rule_id"`
+	HiddenAt time.Time `json:"hidden_at"`
+}
+
+// PostTag is a tag a user put on a post
+type PostTag struct {
+	UserID    uuid.UUID `json:"user_id"`
+	PostID    uuid.UUID `json:"post_id"`
+	Tag       string    `json:"tag"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Writer writes a gzip-compressed JSON-lines archive
+type Writer struct {
+	gz     *gzip.Writer
+	enc    *json.Encoder
+	counts map[string]int
+}
+
+// NewWriter starts an archive on w by writing its header
+func NewWriter(w io.Writer) (*Writer, error) {
+	gz := gzip.NewWriter(w)
+	writer := &Writer{
+		gz:     gz,
+		enc:    json.NewEncoder(gz),
+		counts: make(map[string]int),
+	}
+
+	header := Header{Format: Format, Version: Version, CreatedAt: time.Now().UTC()}
+	if err := writer.enc.Encode(header); err != nil {
+		return nil, fmt.Errorf("failed to write backup header: %w", err)
+	}
+	return writer, nil
+}
+
+// Write appends a record of the given type
+func (w *Writer) Write(recordType string, data any) error {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", recordType, err)
+	}
+	if err := w.enc.Encode(Record{Type: recordType, Data: raw}); err != nil {
+		return fmt.Errorf("failed to write %s: %w", recordType, err)
+	}
+	w.counts[recordType]++
+	return nil
+}
+
+// Counts returns how many records of each type were written
+func (w *Writer) Counts() map[string]int {
+	return w.counts
+}
+
+// Close flushes the archive. It does not close the underlying writer.
+func (w *Writer) Close() error {
+	if err := w.gz.Close(); err != nil {
+		return fmt.Errorf("failed to finish backup: %w", err)
+	}
+	return nil
+}
+
+// Reader reads an archive written by Writer
+type Reader struct {
+	gz      *gzip.Reader
+	scanner *bufio.Scanner
+	header  Header
+	line    int
+}
+
+// NewReader opens an archive and checks its header. Archives written by older
+// versions are read as they are, they simply lack the newer record types.
+func NewReader(r io.Reader) (*Reader, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("not a gator backup: %w", err)
+	}
+
+	scanner := bufio.NewScanner(gz)
+	// Posts with full content can make for long lines
+	scanner.Buffer(make([]byte, 64*1024), 64*1024*1024)
+	reader := &Reader{gz: gz, scanner: scanner}
+
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return nil, fmt.Errorf("failed to read backup header: %w", err)
+		}
+		return nil, fmt.Errorf("backup is empty")
+	}
+	reader.line = 1
+	if err := json.Unmarshal(scanner.Bytes(), &reader.header); err != nil || reader.header.Format != Format {
+		return nil, fmt.Errorf("not a gator backup")
+	}
+	if reader.header.Version < 1 {
+		return nil, fmt.Errorf("invalid backup format version %d", reader.header.Version)
+	}
+	if reader.header.Version > Version {
+		return nil, fmt.Errorf("backup format version %d is newer than this build supports (%d), upgrade gator first",
+			reader.header.Version, Version)
+	}
+
+	return reader, nil
+}
+
+// Header returns the archive header
+func (r *Reader) Header() Header {
+	return r.header
+}
+
+// Next returns the next record, or io.EOF after the last one
+func (r *Reader) Next() (Record, error) {
+	if !r.scanner.Scan() {
+		if err := r.scanner.Err(); err != nil {
+			return Record{}, fmt.Errorf("failed to read backup: %w", err)
+		}
+		return Record{}, io.EOF
+	}
+	r.line++
+
+	var record Record
+	if err := json.Unmarshal(r.scanner.Bytes(), &record); err != nil {
+		return Record{}, fmt.Errorf("invalid record on line %d: %w", r.line, err)
+	}
+	return record, nil
+}
+
+// Decode unmarshals the record data into v
+func (r Record) Decode(v any) error {
+	if err := json.Unmarshal(r.Data, v); err != nil {
+		return fmt.Errorf("invalid %s record: %w", r.Type, err)
+	}
+	return nil
+}
+
+// Close releases the archive. It does not close the underlying reader.
+func (r *Reader) Close() error {
+	return r.gz.Close()
+}
\ No newline at end of file
@@ -0,0 +1,161 @@
+package backup
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// archive gzips the given lines into an archive
+func archive(t *testing.T, lines ...string) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	if _, err := io.WriteString(gz, strings.Join(lines, "\n")); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return &buf
+}
+
+// header returns an archive header line of the given version
+func header(version int) string {
+	return fmt.Sprintf(`{"format":%q,"version":%d,"created_at":"2024-01-02T03:04:05Z"}`, Format, version)
+}
+
+func TestRoundTrip(t *testing.T) {
+	user := User{ID: uuid.New(), CreatedAt: time.Now().UTC().Truncate(time.Second), Name: "alice", Role: "admin"}
+	feed := Feed{ID: uuid.New(), Name: "Alpha", URL: "https://example.com/feed", UserID: user.ID}
+
+	var buf bytes.Buffer
+	writer, err := NewWriter(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, record := range []struct {
+		recordType string
+		data       any
+	}{
+		{TypeUser, user},
+		{TypeFeed, feed},
+	} {
+		if err := writer.Write(record.recordType, record.data); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if counts := writer.Counts(); counts[TypeUser] != 1 || counts[TypeFeed] != 1 || counts[TypePost] != 0 {
+		t.Errorf("got counts %v", counts)
+	}
+
+	reader, err := NewReader(&buf)
+	if err != nil {
+		t.Fatalf("NewReader returned error: %v", err)
+	}
+	defer reader.Close()
+	if got := reader.Header(); got.Format != Format || got.Version != Version {
+		t.Errorf("got header %+v, want format %s version %d", got, Format, Version)
+	}
+
+	record, err := reader.Next()
+	if err != nil || record.Type != TypeUser {
+		t.Fatalf("got record %+v, %v, want a user", record, err)
+	}
+	var gotUser User
+	if err := record.Decode(&gotUser); err != nil {
+		t.Fatal(err)
+	}
+	if gotUser != user {
+		t.Errorf("got user %+v, want %+v", gotUser, user)
+	}
+
+	record, err = reader.Next()
+	if err != nil || record.Type != TypeFeed {
+		t.Fatalf("got record %+v, %v, want a feed", record, err)
+	}
+	if _, err := reader.Next(); err != io.EOF {
+		t.Errorf("got %v after the last record, want io.EOF", err)
+	}
+}
+
+func TestReaderVersions(t *testing.T) {
+	tests := []struct {
+		name    string
+		version int
+		wantErr string
+	}{
+		// Older archives are read as they are, they only lack the newer record types
+		{"first version", 1, ""},
+		{"current version", Version, ""},
+		{"newer version", Version + 1, "upgrade gator first"},
+		{"invalid version", 0, "invalid backup format version"},
+		{"negative version", -1, "invalid backup format version"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader, err := NewReader(archive(t, header(tt.version), `{"type":"user","data":{}}`))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("got error %v, want one containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewReader returned error: %v", err)
+			}
+			defer reader.Close()
+			if got := reader.Header().Version; got != tt.version {
+				t.Errorf("got version %d, want %d", got, tt.version)
+			}
+			if record, err := reader.Next(); err != nil || record.Type != TypeUser {
+				t.Errorf("got record %+v, %v, want a user", record, err)
+			}
+		})
+	}
+}
+
+func TestReaderInvalidArchives(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   io.Reader
+		wantErr string
+	}{
+		{"not gzip", strings.NewReader(header(Version)), "not a gator backup"},
+		{"empty", archive(t), "backup is empty"},
+		{"not json", archive(t, "users,feeds"), "not a gator backup"},
+		{"other format", archive(t, `{"format":"other","version":1}`), "not a gator backup"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewReader(tt.input)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("got error %v, want one containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestReaderInvalidRecord(t *testing.T) {
+	reader, err := NewReader(archive(t, header(Version), `{"type":"user","data":{}}`, `{"type":`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reader.Close()
+
+	if _, err := reader.Next(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := reader.Next(); err == nil || !strings.Contains(err.Error(), "line 3") {
+		t.Errorf("got error %v, want one naming line 3", err)
+	}
+}
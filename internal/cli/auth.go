@@ -0,0 +1,198 @@
+package cli
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/PassZ/rss-aggregator/internal/auth"
+	"github.com/PassZ/rss-aggregator/internal/database"
+	"golang.org/x/term"
+)
+
+// sessionDuration is how long a login lasts before the user has to log in again
+const sessionDuration = 30 * 24 * time.Hour
+
+// sessionTouchInterval is how stale a session's last use may get before a command records
+// it again, so most commands don't have to write to the database
+const sessionTouchInterval = time.Minute
+
+// HandlerLogout handles the logout command
+func HandlerLogout(s *State, cmd Command) error {
+	if s.Config.SessionToken == "" {
+		fmt.Println("You are not logged in.")
+		return nil
+	}
+
+	err := s.DB.DeleteSession(context.Background(), auth.HashToken(s.Config.SessionToken))
+	if err != nil {
+		return fmt.Errorf("failed to end session: %w", err)
+	}
+	if err := s.Config.SetSession(""); err != nil {
+		return fmt.Errorf("failed to clear session: %w", err)
+	}
+
+	fmt.Println("Logged out")
+	return nil
+}
+
+// HandlerPasswd handles the passwd command
+func HandlerPasswd(s *State, cmd Command, user database.User) error {
+	// Changing an existing password requires knowing it
+	if user.PasswordHash.Valid {
+		password, err := readPassword("Current password: ")
+		if err != nil {
+			return err
+		}
+		if err := auth.CheckPassword(user.PasswordHash.String, password); err != nil {
+			return err
+		}
+	}
+
+	hash, err := promptNewPassword()
+	if err != nil {
+		return err
+	}
+	err = s.DB.SetUserPassword(context.Background(), database.SetUserPasswordParams{
+		ID:           user.ID,
+		PasswordHash: hash,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set password: %w", err)
+	}
+
+	// Log out every other session, then start a fresh one here
+	err = s.DB.DeleteUserSessions(context.Background(), user.ID)
+	if err != nil {
+		return fmt.Errorf("failed to end other sessions: %w", err)
+	}
+	if err := startSession(s, user); err != nil {
+		return err
+	}
+
+	if hash.Valid {
+		fmt.Println("Password changed, other sessions have been logged out")
+	} else {
+		fmt.Println("Password removed, other sessions have been logged out")
+	}
+	return nil
+}
+
+// currentUser returns the user of the session stored in the config
+func currentUser(s *State) (database.User, error) {
+	if s.Config.SessionToken == "" {
+		// Configs from before sessions only name the user, who has to prove who they are
+		if s.Config.CurrentUserName != "" {
+			return database.User{}, fmt.Errorf("logins now use sessions, log in again with 'gator login %s'", s.Config.CurrentUserName)
+		}
+		return database.User{}, fmt.Errorf("not logged in, use 'gator login <name>'")
+	}
+
+	tokenHash := auth.HashToken(s.Config.SessionToken)
+	now := time.Now().UTC()
+	session, err := s.DB.GetSessionUser(context.Background(), database.GetSessionUserParams{
+		TokenHash: tokenHash,
+		ExpiresAt: now,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return database.User{}, fmt.Errorf("session expired, use 'gator login <name>' to log in again")
+		}
+		return database.User{}, fmt.Errorf("failed to check session: %w", err)
+	}
+
+	if now.Sub(session.LastUsedAt) >= sessionTouchInterval {
+		err = s.DB.TouchSession(context.Background(), database.TouchSessionParams{
+			TokenHash:  tokenHash,
+			LastUsedAt: now,
+		})
+		if err != nil {
+			return database.User{}, fmt.Errorf("failed to update session: %w", err)
+		}
+	}
+
+	return session.User, nil
+}
+
+// startSession creates a session for the user and stores its token in the config
+func startSession(s *State, user database.User) error {
+	token, err := auth.NewSessionToken()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	_, err = s.DB.CreateSession(context.Background(), database.CreateSessionParams{
+		ID:        uuid.New(),
+		CreatedAt: now,
+		ExpiresAt: now.Add(sessionDuration),
+		UserID:    user.ID,
+		TokenHash: auth.HashToken(token),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create session: %w", err)
+	}
+
+	// Clean up sessions nobody can use anymore
+	if err := s.DB.DeleteExpiredSessions(context.Background(), now); err != nil {
+		return fmt.Errorf("failed to delete expired sessions: %w", err)
+	}
+
+	if err := s.Config.SetSession(token); err != nil {
+		return fmt.Errorf("failed to save session: %w", err)
+	}
+	return nil
+}
+
+// promptNewPassword asks for a new password twice and returns its hash,
+// or NULL when the user leaves it empty
+func promptNewPassword() (sql.NullString, error) {
+	password, err := readPassword("New password (leave empty for none): ")
+	if err != nil {
+		return sql.NullString{}, err
+	}
+	if password == "" {
+		return sql.NullString{}, nil
+	}
+
+	repeated, err := readPassword("Repeat password: ")
+	if err != nil {
+		return sql.NullString{}, err
+	}
+	if repeated != password {
+		return sql.NullString{}, fmt.Errorf("passwords do not match")
+	}
+
+	hash, err := auth.HashPassword(password)
+	if err != nil {
+		return sql.NullString{}, err
+	}
+	return sql.NullString{String: hash, Valid: true}, nil
+}
+
+// readPassword prompts for a password without echoing it. When stdin is not a
+// terminal, as in scripts, the password is read as a plain line.
+func readPassword(prompt string) (string, error) {
+	fmt.Print(prompt)
+
+	fd := int(os.Stdin.Fd())
+	if term.IsTerminal(fd) {
+		password, err := term.ReadPassword(fd)
+		fmt.Println()
+		if err != nil {
+			return "", fmt.Errorf("failed to read password: %w", err)
+		}
+		return string(password), nil
+	}
+
+	line, err := stdin.ReadString('\n')
+	if err != nil && err != io.EOF {
+		return "", fmt.Errorf("failed to read password: %w", err)
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
\ No newline at end of file
@@ -0,0 +1,96 @@
+package cli
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/PassZ/rss-aggregator/internal/auth"
+	"github.com/PassZ/rss-aggregator/internal/config"
+	"github.com/PassZ/rss-aggregator/internal/database"
+)
+
+func TestRegisterAndLogin(t *testing.T) {
+	s, _ := newTestState(t)
+
+	// The first user becomes an admin and is logged in straight away
+	setInput(t, "secret", "secret")
+	mustRun(t, s, "register", "alice")
+	alice := loggedInUser(t, s)
+	if alice.Name != "alice" || alice.Role != roleAdmin {
+		t.Fatalf("got %s with role %s, want alice as admin", alice.Name, alice.Role)
+	}
+	if !alice.PasswordHash.Valid {
+		t.Error("alice has no password")
+	}
+
+	// Everyone after that is a member
+	bob := registerUser(t, s, " bob ")
+	if bob.Name != "bob" || bob.Role != roleMember {
+		t.Fatalf("got %q with role %s, want bob as member", bob.Name, bob.Role)
+	}
+	if _, err := run(t, s, "register", "bob"); err == nil {
+		t.Error("registered bob twice")
+	}
+	if _, err := run(t, s, "register", "  "); err == nil {
+		t.Error("registered a blank username")
+	}
+
+	mustRun(t, s, "logout")
+	if _, err := currentUser(s); err == nil {
+		t.Fatal("still logged in after logout")
+	}
+
+	setInput(t, "wrong")
+	if _, err := run(t, s, "login", "alice"); err == nil {
+		t.Fatal("logged in with a wrong password")
+	}
+	setInput(t, "secret")
+	mustRun(t, s, "login", "alice")
+	if user := loggedInUser(t, s); user.ID != alice.ID {
+		t.Fatalf("logged in as %s, want alice", user.Name)
+	}
+
+	// The session outlives the run through the config file
+	cfg, err := config.Read(s.Config.Path, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.SessionToken == "" || cfg.SessionToken != s.Config.SessionToken {
+		t.Error("session token was not saved to the config file")
+	}
+}
+
+func TestSessionUseIsRecordedAtMostOncePerInterval(t *testing.T) {
+	s, _ := newTestState(t)
+	registerUser(t, s, "alice")
+	tokenHash := auth.HashToken(s.Config.SessionToken)
+
+	// A session used a moment ago is left alone
+	recent := time.Now().UTC().Add(-sessionTouchInterval / 2).Truncate(time.Second)
+	touch(t, s, tokenHash, recent)
+	mustRun(t, s, "following")
+	if got := currentSession(t, s).LastUsedAt; !got.Equal(recent) {
+		t.Errorf("session last used at %v after a command, want it left at %v", got, recent)
+	}
+
+	// and one that hasn't been used for a while is recorded as used again
+	stale := time.Now().UTC().Add(-2 * sessionTouchInterval).Truncate(time.Second)
+	touch(t, s, tokenHash, stale)
+	mustRun(t, s, "following")
+	if got := currentSession(t, s).LastUsedAt; !got.After(stale) {
+		t.Errorf("session last used at %v after a command, want it updated", got)
+	}
+}
+
+// touch sets when a session was last used
+func touch(t *testing.T, s *State, tokenHash string, at time.Time) {
+	t.Helper()
+	err := s.DB.TouchSession(context.Background(), database.TouchSessionParams{
+		TokenHash:  tokenHash,
+		LastUsedAt: at,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
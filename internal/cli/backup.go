@@ -0,0 +1,868 @@
+package cli
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/PassZ/rss-aggregator/internal/backup"
+	"github.com/PassZ/rss-aggregator/internal/database"
+)
+
+// backupDirName is where automatic backups are kept, relative to the home directory
+const backupDirName = ".gator/backups"
+
+// HandlerBackup handles the backup command
+func HandlerBackup(s *State, cmd Command, user database.User) error {
+	fs := newFlagSet(cmd)
+	force := fs.Bool("force", false, "overwrite the file if it exists")
+	args, err := parseFlags(fs, cmd.Args)
+	if err != nil {
+		return err
+	}
+
+	// Check if the command has the required argument
+	if len(args) == 0 {
+		return fmt.Errorf("usage: backup <file> [--force]")
+	}
+	if _, err := os.Stat(args[0]); err == nil && !*force {
+		return fmt.Errorf("%s already exists, use --force to overwrite it", args[0])
+	}
+
+	counts, err := writeBackup(s, args[0])
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Backup written to %s\n", args[0])
+	for _, recordType := range backup.Types {
+		fmt.Printf("  %s: %d\n", recordType, counts[recordType])
+	}
+	return nil
+}
+
+// HandlerRestore handles the restore command
+func HandlerRestore(s *State, cmd Command) error {
+	fs := newFlagSet(cmd)
+	dryRun := fs.Bool("dry-run", false, "show what would be restored without changing anything")
+	args, err := parseFlags(fs, cmd.Args)
+	if err != nil {
+		return err
+	}
+
+	// Check if the command has the required argument
+	if len(args) == 0 {
+		return fmt.Errorf("usage: restore <file> [--dry-run]")
+	}
+
+	// Only admins may restore, except into an empty database such as after a full reset
+	users, err := s.DB.GetUsers(context.Background())
+	if err != nil {
+		return fmt.Errorf("failed to get users: %w", err)
+	}
+	loc := time.Local
+	if len(users) > 0 {
+		user, err := currentUser(s)
+		if err != nil {
+			return err
+		}
+		if user.Role != roleAdmin {
+			return fmt.Errorf("only admins can use the %s command", cmd.Name)
+		}
+		loc = userLocation(user)
+	}
+
+	file, err := os.Open(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to open backup: %w", err)
+	}
+	defer file.Close()
+
+	reader, err := backup.NewReader(file)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	header := reader.Header()
+	fmt.Printf("Restoring backup from %s (format version %d)\n", formatTime(header.CreatedAt, loc), header.Version)
+
+	// Load everything in one transaction so a failed restore leaves the database untouched
+	tx, err := s.DB.BeginTx(context.Background(), nil)
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	r := newRestorer(tx)
+	for {
+		record, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if err := r.restore(record); err != nil {
+			return err
+		}
+	}
+
+	// Summarise what happened to each record type
+	for _, recordType := range backup.Types {
+		result, ok := r.results[recordType]
+		if !ok {
+			continue
+		}
+		fmt.Printf("  %s: %d restored, %d already present, %d matched by name or URL, %d skipped\n",
+			recordType, result.restored, result.existing, result.remapped, result.skipped)
+	}
+	if r.unknown > 0 {
+		fmt.Printf("  %d record(s) of unknown types skipped, the backup was written by a newer version\n", r.unknown)
+	}
+
+	if *dryRun {
+		fmt.Println("Dry run, nothing was restored.")
+		return nil
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit restore: %w", err)
+	}
+	return nil
+}
+
+// restoreResult counts what happened to the records of one type
+type restoreResult struct {
+	restored int
+	existing int
+	remapped int
+	skipped  int
+}
+
+// restorer loads archive records into the database. Records that already exist are
+// kept as they are, and the IDs of the existing rows are remembered so records
+// referring to them still land in the right place. Users and feeds that exist under
+// another ID are matched by name or URL, so a backup can be merged into a database
+// that has since been set up again.
+type restorer struct {
+	q       database.Querier
+	users   map[uuid.UUID]uuid.UUID
+	feeds   map[uuid.UUID]uuid.UUID
+	follows map[uuid.UUID]uuid.UUID
+	posts   map[uuid.UUID]uuid.UUID
+	rules   map[uuid.UUID]uuid.UUID
+	results map[string]*restoreResult
+	unknown int
+}
+
+// newRestorer returns a restorer that writes through q
+func newRestorer(q database.Querier) *restorer {
+	results := make(map[string]*restoreResult)
+	for _, recordType := range backup.Types {
+		results[recordType] = &restoreResult{}
+	}
+	return &restorer{
+		q:       q,
+		users:   make(map[uuid.UUID]uuid.UUID),
+		feeds:   make(map[uuid.UUID]uuid.UUID),
+		follows: make(map[uuid.UUID]uuid.UUID),
+		posts:   make(map[uuid.UUID]uuid.UUID),
+		rules:   make(map[uuid.UUID]uuid.UUID),
+		results: results,
+	}
+}
+
+// restore loads a single record. Records whose user, feed or post did not make it
+// into the database are skipped.
+func (r *restorer) restore(record backup.Record) error {
+	ctx := context.Background()
+	result, ok := r.results[record.Type]
+	if !ok {
+		// Skip record types from newer builds rather than failing the whole restore
+		r.unknown++
+		return nil
+	}
+
+	switch record.Type {
+	case backup.TypeUser:
+		var u backup.User
+		if err := record.Decode(&u); err != nil {
+			return err
+		}
+		id, err := r.q.RestoreUser(ctx, database.RestoreUserParams{
+			ID:           u.ID,
+			CreatedAt:    u.CreatedAt,
+			UpdatedAt:    u.UpdatedAt,
+			Name:         u.Name,
+			Role:         u.Role,
+			Timezone:     toNullString(u.Timezone),
+			PasswordHash: toNullString(u.PasswordHash),
+		})
+		id, err = resolveRestored(result, u.ID, id, err, func() (uuid.UUID, error) {
+			return r.q.FindRestoredUser(ctx, database.FindRestoredUserParams{ID: u.ID, Name: u.Name})
+		})
+		if err != nil {
+			return fmt.Errorf("failed to restore user %s: %w", u.Name, err)
+		}
+		r.users[u.ID] = id
+
+	case backup.TypeFeed:
+		var f backup.Feed
+		if err := record.Decode(&f); err != nil {
+			return err
+		}
+		owner, ok := r.users[f.UserID]
+		if !ok {
+			result.skipped++
+			return nil
+		}
+		id, err := r.q.RestoreFeed(ctx, database.RestoreFeedParams{
+			ID:                  f.ID,
+			CreatedAt:           f.CreatedAt,
+			UpdatedAt:           f.UpdatedAt,
+			Name:                f.Name,
+			Url:                 f.URL,
+			UserID:              owner,
+			LastFetchedAt:       toNullTime(f.LastFetchedAt),
+			Title:               toNullString(f.Title),
+			Description:         toNullString(f.Description),
+			Link:                toNullString(f.Link),
+			Language:            toNullString(f.Language),
+			ImageUrl:            toNullString(f.ImageURL),
+			Paused:              f.Paused,
+			UrlKey:              toNullString(f.URLKey),
+			ContentHash:         toNullString(f.ContentHash),
+			RetentionMaxAgeDays: toNullInt32(f.RetentionMaxAgeDays),
+			RetentionMaxPosts:   toNullInt32(f.RetentionMaxPosts),
+		})
+		id, err = resolveRestored(result, f.ID, id, err, func() (uuid.UUID, error) {
+			return r.q.FindRestoredFeed(ctx, database.FindRestoredFeedParams{
+				ID:     f.ID,
+				Url:    f.URL,
+				UrlKey: toNullString(f.URLKey),
+			})
+		})
+		if err != nil {
+			return fmt.Errorf("failed to restore feed %s: %w", f.URL, err)
+		}
+		r.feeds[f.ID] = id
+
+	case backup.TypeFeedFollow:
+		var ff backup.FeedFollow
+		if err := record.Decode(&ff); err != nil {
+			return err
+		}
+		userID, userOK := r.users[ff.UserID]
+		feedID, feedOK := r.feeds[ff.FeedID]
+		if !userOK || !feedOK {
+			result.skipped++
+			return nil
+		}
+		id, err := r.q.RestoreFeedFollow(ctx, database.RestoreFeedFollowParams{
+			ID:          ff.ID,
+			CreatedAt:   ff.CreatedAt,
+			UpdatedAt:   ff.UpdatedAt,
+			UserID:      userID,
+			FeedID:      feedID,
+			CustomTitle: toNullString(ff.CustomTitle),
+			Muted:       ff.Muted,
+			Priority:    ff.Priority,
+			Notify:      ff.Notify,
+		})
+		id, err = resolveRestored(result, ff.ID, id, err, func() (uuid.UUID, error) {
+			return r.q.FindRestoredFeedFollow(ctx, database.FindRestoredFeedFollowParams{
+				ID:     ff.ID,
+				UserID: userID,
+				FeedID: feedID,
+			})
+		})
+		if err != nil {
+			return fmt.Errorf("failed to restore feed follow: %w", err)
+		}
+		r.follows[ff.ID] = id
+
+	case backup.TypeFeedFollowTag:
+		var t backup.FeedFollowTag
+		if err := record.Decode(&t); err != nil {
+			return err
+		}
+		followID, ok := r.follows[t.FeedFollowID]
+		if !ok {
+			result.skipped++
+			return nil
+		}
+		rows, err := r.q.RestoreFeedFollowTag(ctx, database.RestoreFeedFollowTagParams{
+			FeedFollowID: followID,
+			Tag:          t.Tag,
+			CreatedAt:    t.CreatedAt,
+		})
+		if err := countRestored(result, rows, err); err != nil {
+			return fmt.Errorf("failed to restore feed follow tag: %w", err)
+		}
+
+	case backup.TypePost:
+		var p backup.Post
+		if err := record.Decode(&p); err != nil {
+			return err
+		}
+		feedID, ok := r.feeds[p.FeedID]
+		if !ok {
+			result.skipped++
+			return nil
+		}
+		id, err := r.q.RestorePost(ctx, database.RestorePostParams{
+			ID:          p.ID,
+			CreatedAt:   p.CreatedAt,
+			UpdatedAt:   p.UpdatedAt,
+			Title:       p.Title,
+			Url:         p.URL,
+			Description: toNullString(p.Description),
+			PublishedAt: toNullTime(p.PublishedAt),
+			FeedID:      feedID,
+			Content:     toNullString(p.Content),
+			Author:      toNullString(p.Author),
+			Categories:  toNullString(p.Categories),
+			Guid:        toNullString(p.GUID),
+		})
+		id, err = resolveRestored(result, p.ID, id, err, func() (uuid.UUID, error) {
+			return r.q.FindRestoredPost(ctx, database.FindRestoredPostParams{ID: p.ID, Url: p.URL})
+		})
+		if err != nil {
+			return fmt.Errorf("failed to restore post %s: %w", p.URL, err)
+		}
+		r.posts[p.ID] = id
+
+	case backup.TypePostTombstone:
+		var t backup.PostTombstone
+		if err := record.Decode(&t); err != nil {
+			return err
+		}
+		feedID, ok := r.feeds[t.FeedID]
+		if !ok {
+			result.skipped++
+			return nil
+		}
+		rows, err := r.q.RestorePostTombstone(ctx, database.RestorePostTombstoneParams{
+			FeedID:   feedID,
+			Guid:     t.GUID,
+			PrunedAt: t.PrunedAt,
+		})
+		if err := countRestored(result, rows, err); err != nil {
+			return fmt.Errorf("failed to restore post tombstone: %w", err)
+		}
+
+	case backup.TypeRule:
+		var rule backup.Rule
+		if err := record.Decode(&rule); err != nil {
+			return err
+		}
+		userID, ok := r.users[rule.UserID]
+		if !ok {
+			result.skipped++
+			return nil
+		}
+		rows, err := r.q.RestoreRule(ctx, database.RestoreRuleParams{
+			ID:        rule.ID,
+			CreatedAt: rule.CreatedAt,
+			UpdatedAt: rule.UpdatedAt,
+			UserID:    userID,
+			Field:     rule.Field,
+			MatchType: rule.MatchType,
+			Pattern:   rule.Pattern,
+			Action:    rule.Action,
+			Tag:       toNullString(rule.Tag),
+		})
+		if err := countRestored(result, rows, err); err != nil {
+			return fmt.Errorf("failed to restore rule: %w", err)
+		}
+		// Rules only clash on their ID, so an existing one is the same rule
+		r.rules[rule.ID] = rule.ID
+
+	case backup.TypePostRead:
+		var read backup.PostRead
+		if err := record.Decode(&read); err != nil {
+			return err
+		}
+		userID, userOK := r.users[read.UserID]
+		postID, postOK := r.posts[read.PostID]
+		if !userOK || !postOK {
+			result.skipped++
+			return nil
+		}
+		rows, err := r.q.RestorePostRead(ctx, database.RestorePostReadParams{
+			UserID: userID,
+			PostID: postID,
+			ReadAt: read.ReadAt,
+		})
+		if err := countRestored(result, rows, err); err != nil {
+			return fmt.Errorf("failed to restore read state: %w", err)
+		}
+
+	case backup.TypePostStar:
+		var star backup.PostStar
+		if err := record.Decode(&star); err != nil {
+			return err
+		}
+		userID, ok := r.users[star.UserID]
+		if !ok {
+			result.skipped++
+			return nil
+		}
+		// Stars keep their copy of the post, so they are restored even if the post is gone
+		var postID uuid.NullUUID
+		if star.PostID != nil {
+			if id, ok := r.posts[*star.PostID]; ok {
+				postID = uuid.NullUUID{UUID: id, Valid: true}
+			}
+		}
+		rows, err := r.q.RestorePostStar(ctx, database.RestorePostStarParams{
+			ID:          star.ID,
+			CreatedAt:   star.CreatedAt,
+			UpdatedAt:   star.UpdatedAt,
+			UserID:      userID,
+			PostID:      postID,
+			PostUrl:     star.PostURL,
+			Title:       star.Title,
+			Description: toNullString(star.Description),
+			PublishedAt: toNullTime(star.PublishedAt),
+			FeedName:    star.FeedName,
+			Note:        toNullString(star.Note),
+		})
+		if err := countRestored(result, rows, err); err != nil {
+			return fmt.Errorf("failed to restore star: %w", err)
+		}
+
+	case backup.TypePostHide:
+		var hide backup.PostHide
+		if err := record.Decode(&hide); err != nil {
+			return err
+		}
+		userID, userOK := r.users[hide.UserID]
+		postID, postOK := r.posts[hide.PostID]
+		ruleID, ruleOK := r.rules[hide.RuleID]
+		if !userOK || !postOK || !ruleOK {
+			result.skipped++
+			return nil
+		}
+		rows, err := r.q.RestorePostHide(ctx, database.RestorePostHideParams{
+			UserID:   userID,
+			PostID:   postID,
+			RuleID:   ruleID,
+			HiddenAt: hide.HiddenAt,
+		})
+		if err := countRestored(result, rows, err); err != nil {
+			return fmt.Errorf("failed to restore hidden post: %w", err)
+		}
+
+	case backup.TypePostTag:
+		var tag backup.PostTag
+		if err := record.Decode(&tag); err != nil {
+			return err
+		}
+		userID, userOK := r.users[tag.UserID]
+		postID, postOK := r.posts[tag.PostID]
+		if !userOK || !postOK {
+			result.skipped++
+			return nil
+		}
+		rows, err := r.q.RestorePostTag(ctx, database.RestorePostTagParams{
+			UserID:    userID,
+			PostID:    postID,
+			Tag:       tag.Tag,
+			CreatedAt: tag.CreatedAt,
+		})
+		if err := countRestored(result, rows, err); err != nil {
+			return fmt.Errorf("failed to restore post tag: %w", err)
+		}
+	}
+	return nil
+}
+
+// resolveRestored counts the outcome of restoring a record that others refer to and
+// returns the ID it ended up under. A restore that returned no row clashed with an
+// existing record, which find looks up.
+func resolveRestored(result *restoreResult, backupID, id uuid.UUID, err error, find func() (uuid.UUID, error)) (uuid.UUID, error) {
+	if err == nil {
+		result.restored++
+		return id, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return uuid.Nil, err
+	}
+
+	id, err = find()
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if id == backupID {
+		result.existing++
+	} else {
+		result.remapped++
+	}
+	return id, nil
+}
+
+// countRestored counts the outcome of restoring a record nothing refers to
+func countRestored(result *restoreResult, rows int64, err error) error {
+	if err != nil {
+		return err
+	}
+	if rows > 0 {
+		result.restored++
+	} else {
+		result.existing++
+	}
+	return nil
+}
+
+// autoBackup writes a backup of the whole database before a destructive operation
+// and prints where it went
+func autoBackup(s *State, operation string) error {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return fmt.Errorf("failed to find home directory: %w", err)
+	}
+	dir := filepath.Join(homeDir, backupDirName)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("failed to create backup directory: %w", err)
+	}
+
+	// Operations can follow each other within a second, never replace an earlier backup
+	stamp := time.Now().UTC().Format("20060102-150405")
+	path := filepath.Join(dir, fmt.Sprintf("%s-%s.jsonl.gz", operation, stamp))
+	for i := 2; ; i++ {
+		if _, err := os.Stat(path); err != nil {
+			break
+		}
+		path = filepath.Join(dir, fmt.Sprintf("%s-%s-%d.jsonl.gz", operation, stamp, i))
+	}
+	if _, err := writeBackup(s, path); err != nil {
+		return err
+	}
+
+	fmt.Printf("Backup written to %s, use 'gator restore %s' to undo\n", path, path)
+	return nil
+}
+
+// writeBackup writes the whole database, apart from icons and sessions, to a backup
+// file and returns how many records of each type it holds
+func writeBackup(s *State, path string) (map[string]int, error) {
+	// Write to a temporary file next to the backup and only replace it once the export
+	// succeeded, so a failure never destroys an existing backup. CreateTemp makes the file
+	// private, which backups need as they contain password hashes.
+	file, err := os.CreateTemp(filepath.Dir(path), ".gator-backup-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create backup: %w", err)
+	}
+	defer file.Close()
+	// Does nothing once the file has been renamed
+	defer os.Remove(file.Name())
+
+	// Read everything in one snapshot so the records agree with each other
+	tx, err := s.DB.BeginTx(context.Background(), &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	writer, err := backup.NewWriter(file)
+	if err != nil {
+		return nil, err
+	}
+	if err := writeRecords(tx, writer); err != nil {
+		return nil, err
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	if err := file.Sync(); err != nil {
+		return nil, fmt.Errorf("failed to write backup: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write backup: %w", err)
+	}
+	if err := os.Rename(file.Name(), path); err != nil {
+		return nil, fmt.Errorf("failed to write backup: %w", err)
+	}
+	return writer.Counts(), nil
+}
+
+// writeRecords writes every record type in backup.Types order
+func writeRecords(q database.Querier, writer *backup.Writer) error {
+	ctx := context.Background()
+
+	users, err := q.GetUsers(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get users: %w", err)
+	}
+	for _, u := range users {
+		err := writer.Write(backup.TypeUser, backup.User{
+			ID:           u.ID,
+			CreatedAt:    u.CreatedAt,
+			UpdatedAt:    u.UpdatedAt,
+			Name:         u.Name,
+			Role:         u.Role,
+			Timezone:     fromNullString(u.Timezone),
+			PasswordHash: fromNullString(u.PasswordHash),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	feeds, err := q.GetAllFeeds(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get feeds: %w", err)
+	}
+	for _, f := range feeds {
+		err := writer.Write(backup.TypeFeed, backup.Feed{
+			ID:                  f.ID,
+			CreatedAt:           f.CreatedAt,
+			UpdatedAt:           f.UpdatedAt,
+			Name:                f.Name,
+			URL:                 f.Url,
+			UserID:              f.UserID,
+			LastFetchedAt:       fromNullTime(f.LastFetchedAt),
+			Title:               fromNullString(f.Title),
+			Description:         fromNullString(f.Description),
+			Link:                fromNullString(f.Link),
+			Language:            fromNullString(f.Language),
+			ImageURL:            fromNullString(f.ImageUrl),
+			Paused:              f.Paused,
+			URLKey:              fromNullString(f.UrlKey),
+			ContentHash:         fromNullString(f.ContentHash),
+			RetentionMaxAgeDays: fromNullInt32(f.RetentionMaxAgeDays),
+			RetentionMaxPosts:   fromNullInt32(f.RetentionMaxPosts),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	follows, err := q.GetAllFeedFollows(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get feed follows: %w", err)
+	}
+	for _, ff := range follows {
+		err := writer.Write(backup.TypeFeedFollow, backup.FeedFollow{
+			ID:          ff.ID,
+			CreatedAt:   ff.CreatedAt,
+			UpdatedAt:   ff.UpdatedAt,
+			UserID:      ff.UserID,
+			FeedID:      ff.FeedID,
+			CustomTitle: fromNullString(ff.CustomTitle),
+			Muted:       ff.Muted,
+			Priority:    ff.Priority,
+			Notify:      ff.Notify,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	followTags, err := q.GetAllFeedFollowTags(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get feed follow tags: %w", err)
+	}
+	for _, t := range followTags {
+		err := writer.Write(backup.TypeFeedFollowTag, backup.FeedFollowTag{
+			FeedFollowID: t.FeedFollowID,
+			Tag:          t.Tag,
+			CreatedAt:    t.CreatedAt,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	posts, err := q.GetAllPosts(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get posts: %w", err)
+	}
+	for _, p := range posts {
+		err := writer.Write(backup.TypePost, backup.Post{
+			ID:          p.ID,
+			CreatedAt:   p.CreatedAt,
+			UpdatedAt:   p.UpdatedAt,
+			Title:       p.Title,
+			URL:         p.Url,
+			Description: fromNullString(p.Description),
+			PublishedAt: fromNullTime(p.PublishedAt),
+			FeedID:      p.FeedID,
+			Content:     fromNullString(p.Content),
+			Author:      fromNullString(p.Author),
+			Categories:  fromNullString(p.Categories),
+			GUID:        fromNullString(p.Guid),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	tombstones, err := q.GetAllPostTombstones(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get post tombstones: %w", err)
+	}
+	for _, t := range tombstones {
+		err := writer.Write(backup.TypePostTombstone, backup.PostTombstone{
+			FeedID:   t.FeedID,
+			GUID:     t.Guid,
+			PrunedAt: t.PrunedAt,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	rules, err := q.GetAllRules(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get rules: %w", err)
+	}
+	for _, rule := range rules {
+		err := writer.Write(backup.TypeRule, backup.Rule{
+			ID:        rule.ID,
+			CreatedAt: rule.CreatedAt,
+			UpdatedAt: rule.UpdatedAt,
+			UserID:    rule.UserID,
+			Field:     rule.Field,
+			MatchType: rule.MatchType,
+			Pattern:   rule.Pattern,
+			Action:    rule.Action,
+			Tag:       fromNullString(rule.Tag),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	reads, err := q.GetAllPostReads(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get read state: %w", err)
+	}
+	for _, read := range reads {
+		err := writer.Write(backup.TypePostRead, backup.PostRead{
+			UserID: read.UserID,
+			PostID: read.PostID,
+			ReadAt: read.ReadAt,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	stars, err := q.GetAllPostStars(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get stars: %w", err)
+	}
+	for _, star := range stars {
+		var postID *uuid.UUID
+		if star.PostID.Valid {
+			postID = &star.PostID.UUID
+		}
+		err := writer.Write(backup.TypePostStar, backup.PostStar{
+			ID:          star.ID,
+			CreatedAt:   star.CreatedAt,
+			UpdatedAt:   star.UpdatedAt,
+			UserID:      star.UserID,
+			PostID:      postID,
+			PostURL:     star.PostUrl,
+			Title:       star.Title,
+			Description: fromNullString(star.Description),
+			PublishedAt: fromNullTime(star.PublishedAt),
+			FeedName:    star.FeedName,
+			Note:        fromNullString(star.Note),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	hides, err := q.GetAllPostHides(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get hidden posts: %w", err)
+	}
+	for _, hide := range hides {
+		err := writer.Write(backup.TypePostHide, backup.PostHide{
+			UserID:   hide.UserID,
+			PostID:   hide.PostID,
+			RuleID:   hide.RuleID,
+			HiddenAt: hide.HiddenAt,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	tags, err := q.GetAllPostTags(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get post tags: %w", err)
+	}
+	for _, tag := range tags {
+		err := writer.Write(backup.TypePostTag, backup.PostTag{
+			UserID:    tag.UserID,
+			PostID:    tag.PostID,
+			Tag:       tag.Tag,
+			CreatedAt: tag.CreatedAt,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// fromNullString converts a nullable column to the pointer used in backups
+func fromNullString(value sql.NullString) *string {
+	if !value.Valid {
+		return nil
+	}
+	return &value.String
+}
+
+// toNullString converts a backup pointer back to a nullable column
+func toNullString(value *string) sql.NullString {
+	if value == nil {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: *value, Valid: true}
+}
+
+// fromNullTime converts a nullable column to the pointer used in backups
+func fromNullTime(value sql.NullTime) *time.Time {
+	if !value.Valid {
+		return nil
+	}
+	return &value.Time
+}
+
+// toNullTime converts a backup pointer back to a nullable column
+func toNullTime(value *time.Time) sql.NullTime {
+	if value == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: *value, Valid: true}
+}
+
+// fromNullInt32 converts a nullable column to the pointer used in backups
+func fromNullInt32(value sql.NullInt32) *int32 {
+	if !value.Valid {
+		return nil
+	}
+	return &value.Int32
+}
+
+// toNullInt32 converts a backup pointer back to a nullable column
+func toNullInt32(value *int32) sql.NullInt32 {
+	if value == nil {
+		return sql.NullInt32{}
+	}
+	return sql.NullInt32{Int32: *value, Valid: true}
+}
\ No newline at end of file
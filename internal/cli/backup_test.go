@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestBackupRestore(t *testing.T) {
+	s, server := newTestState(t)
+	setInput(t, "secret", "secret")
+	mustRun(t, s, "register", "alice")
+	mustRun(t, s, "addfeed", server.URL+"/alpha.xml")
+	aggregate(t, s)
+	mustRun(t, s, "markread", "--all")
+	mustRun(t, s, "rules", "add", "postgres", "--action", "star")
+	mustRun(t, s, "rules", "apply")
+	registerUser(t, s, "bob")
+	mustRun(t, s, "follow", server.URL+"/alpha.xml")
+	setInput(t, "secret")
+	mustRun(t, s, "login", "alice")
+	want := userStats(t, s)
+
+	path := filepath.Join(t.TempDir(), "gator.jsonl.gz")
+	mustRun(t, s, "backup", path)
+	if _, err := run(t, s, "backup", path); err == nil {
+		t.Error("backup overwrote an existing file without --force")
+	}
+
+	// Anyone may restore into a database without users
+	restored, _ := newTestState(t)
+	mustRun(t, restored, "restore", path)
+
+	users, err := restored.DB.GetUsers(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(users) != 2 {
+		t.Fatalf("got %d user(s), want 2", len(users))
+	}
+	setInput(t, "secret")
+	mustRun(t, restored, "login", "alice")
+	if got := userStats(t, restored); got != want {
+		t.Errorf("got stats %+v after restoring, want %+v", got, want)
+	}
+
+	// Restoring again keeps what is already there
+	mustRun(t, restored, "restore", path)
+	if got := userStats(t, restored); got != want {
+		t.Errorf("got stats %+v after restoring twice, want %+v", got, want)
+	}
+
+	// Once there are users only admins may restore
+	setInput(t, "")
+	mustRun(t, restored, "login", "bob")
+	if _, err := run(t, restored, "restore", path); err == nil {
+		t.Error("a member restored a backup")
+	}
+}
+
+func TestBackupFailureKeepsExistingFile(t *testing.T) {
+	s, _ := newTestState(t)
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "gator.jsonl.gz")
+	if err := os.WriteFile(path, []byte("previous backup"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	// The export fails once the database is gone
+	s.DB.Close()
+	if _, err := writeBackup(s, path); err == nil {
+		t.Fatal("backup succeeded without a database")
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "previous backup" {
+		t.Errorf("a failed backup replaced the existing file with %q", data)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("a failed backup left %d file(s) behind, want only the existing backup", len(entries)-1)
+	}
+}
@@ -0,0 +1,304 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/PassZ/rss-aggregator/internal/auth"
+	"github.com/PassZ/rss-aggregator/internal/config"
+	"github.com/PassZ/rss-aggregator/internal/database"
+	"github.com/PassZ/rss-aggregator/internal/migrate"
+	"github.com/PassZ/rss-aggregator/internal/rss"
+	"github.com/PassZ/rss-aggregator/internal/store"
+	"github.com/PassZ/rss-aggregator/internal/store/memstore"
+)
+
+// envTestPostgresURL names a Postgres database to run the tests against instead of the
+// in-memory SQLite one, so the Postgres queries are tested too. Each test gets a schema
+// of its own in it, which is dropped when the test is done.
+const envTestPostgresURL = "GATOR_TEST_POSTGRES_URL"
+
+// newTestState returns a State backed by an empty database, a config file in a temporary
+// directory and a fetcher for the server, which serves the feeds in testdata
+func newTestState(t *testing.T) (*State, *httptest.Server) {
+	t.Helper()
+
+	// Keep the real config, environment and home directory out of the tests
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+	for _, name := range []string{
+		config.EnvConfig,
+		config.EnvProfile,
+		config.EnvDbURL,
+		config.EnvRetentionMaxAgeDays,
+		config.EnvRetentionMaxPosts,
+	} {
+		t.Setenv(name, "")
+	}
+
+	// Prompts read from setInput, and passwords are never read from a terminal
+	devNull, err := os.Open(os.DevNull)
+	if err != nil {
+		t.Fatal(err)
+	}
+	realStdin, realReader := os.Stdin, stdin
+	os.Stdin = devNull
+	t.Cleanup(func() {
+		os.Stdin, stdin = realStdin, realReader
+		devNull.Close()
+	})
+	setInput(t)
+
+	st := newTestStore(t)
+
+	cfg, err := config.Read(filepath.Join(t.TempDir(), "config.json"), "")
+	if err != nil {
+		t.Fatalf("failed to read config: %v", err)
+	}
+
+	server := httptest.NewServer(http.FileServer(http.Dir("testdata")))
+	t.Cleanup(server.Close)
+
+	return &State{DB: st, Config: cfg, Fetcher: rss.NewClient(server.Client())}, server
+}
+
+// newTestStore returns an empty, migrated store, in memory unless GATOR_TEST_POSTGRES_URL
+// is set
+func newTestStore(t *testing.T) store.Store {
+	t.Helper()
+	dbURL := os.Getenv(envTestPostgresURL)
+	if dbURL == "" {
+		st, err := memstore.New(context.Background())
+		if err != nil {
+			t.Fatalf("failed to create store: %v", err)
+		}
+		t.Cleanup(func() { st.Close() })
+		return st
+	}
+
+	// Keep each test's tables apart in a schema of its own
+	schemaName := "gator_test_" + strings.ReplaceAll(uuid.NewString(), "-", "")
+	admin, err := sql.Open("postgres", dbURL)
+	if err != nil {
+		t.Fatalf("failed to open %s: %v", envTestPostgresURL, err)
+	}
+	if _, err := admin.Exec("CREATE SCHEMA " + schemaName); err != nil {
+		admin.Close()
+		t.Fatalf("failed to create test schema: %v", err)
+	}
+	t.Cleanup(func() {
+		if _, err := admin.Exec("DROP SCHEMA " + schemaName + " CASCADE"); err != nil {
+			t.Errorf("failed to drop test schema: %v", err)
+		}
+		admin.Close()
+	})
+
+	parsed, err := url.Parse(dbURL)
+	if err != nil {
+		t.Fatalf("invalid %s: %v", envTestPostgresURL, err)
+	}
+	query := parsed.Query()
+	query.Set("search_path", schemaName)
+	parsed.RawQuery = query.Encode()
+	st, err := store.Open(parsed.String())
+	if err != nil {
+		t.Fatalf("failed to open store: %v", err)
+	}
+	t.Cleanup(func() { st.Close() })
+
+	provider, err := migrate.NewProvider(st)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := provider.Up(context.Background()); err != nil {
+		t.Fatalf("failed to migrate test schema: %v", err)
+	}
+	return st
+}
+
+// setInput makes the following prompts read the given lines
+func setInput(t *testing.T, lines ...string) {
+	t.Helper()
+	stdin = bufio.NewReader(strings.NewReader(strings.Join(lines, "\n") + "\n"))
+}
+
+// capture runs f and returns what it printed
+func capture(t *testing.T, f func() error) (string, error) {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	output := make(chan string)
+	go func() {
+		data, _ := io.ReadAll(r)
+		output <- string(data)
+	}()
+
+	realStdout := os.Stdout
+	os.Stdout = w
+	err = f()
+	os.Stdout = realStdout
+	w.Close()
+	out := <-output
+	r.Close()
+	return out, err
+}
+
+// run runs a command and returns what it printed
+func run(t *testing.T, s *State, name string, args ...string) (string, error) {
+	t.Helper()
+	return capture(t, func() error {
+		commands := NewCommands()
+		RegisterCommands(commands)
+		return commands.Run(s, Command{Name: name, Args: args})
+	})
+}
+
+// mustRun runs a command, failing the test when it returns an error
+func mustRun(t *testing.T, s *State, name string, args ...string) string {
+	t.Helper()
+	out, err := run(t, s, name, args...)
+	if err != nil {
+		t.Fatalf("%s %s: %v\n%s", name, strings.Join(args, " "), err, out)
+	}
+	return out
+}
+
+// registerUser registers a user without a password, leaving them logged in
+func registerUser(t *testing.T, s *State, name string) database.User {
+	t.Helper()
+	setInput(t, "")
+	mustRun(t, s, "register", name)
+	return loggedInUser(t, s)
+}
+
+// loggedInUser returns the user of the current session
+func loggedInUser(t *testing.T, s *State) database.User {
+	t.Helper()
+	return currentSession(t, s).User
+}
+
+// currentSession looks up the session stored in the config. Unlike currentUser it never
+// records the session as used, so checking who is logged in doesn't change what the test
+// looks at.
+func currentSession(t *testing.T, s *State) database.GetSessionUserRow {
+	t.Helper()
+	session, err := s.DB.GetSessionUser(context.Background(), database.GetSessionUserParams{
+		TokenHash: auth.HashToken(s.Config.SessionToken),
+		ExpiresAt: time.Now().UTC(),
+	})
+	if err != nil {
+		t.Fatalf("no user logged in: %v", err)
+	}
+	return session
+}
+
+// postID returns the ID of the post with the given title in the feeds the logged in
+// user follows
+func postID(t *testing.T, s *State, title string) uuid.UUID {
+	t.Helper()
+	posts, err := s.DB.GetPostsForRules(context.Background(), loggedInUser(t, s).ID)
+	if err != nil {
+		t.Fatalf("failed to get posts: %v", err)
+	}
+	for _, post := range posts {
+		if post.Title == title {
+			return post.ID
+		}
+	}
+	t.Fatalf("no post titled %q", title)
+	return uuid.Nil
+}
+
+// getFeed returns the feed with the given URL
+func getFeed(t *testing.T, s *State, feedURL string) database.Feed {
+	t.Helper()
+	feed, err := findFeedByURL(s, feedURL)
+	if err != nil {
+		t.Fatalf("failed to get feed %s: %v", feedURL, err)
+	}
+	return feed
+}
+
+// getUser returns the user with the given name
+func getUser(t *testing.T, s *State, name string) database.User {
+	t.Helper()
+	user, err := s.DB.GetUser(context.Background(), name)
+	if err != nil {
+		t.Fatalf("failed to get user %s: %v", name, err)
+	}
+	return user
+}
+
+// backups returns the automatic backups written so far
+func backups(t *testing.T) []string {
+	t.Helper()
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		t.Fatal(err)
+	}
+	paths, err := filepath.Glob(filepath.Join(homeDir, backupDirName, "*.jsonl.gz"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return paths
+}
+
+// aggregate fetches every feed once, as agg does on each tick
+func aggregate(t *testing.T, s *State) {
+	t.Helper()
+	feeds, err := s.DB.GetFeeds(context.Background())
+	if err != nil {
+		t.Fatalf("failed to get feeds: %v", err)
+	}
+	capture(t, func() error {
+		for range feeds {
+			scrapeFeeds(s)
+		}
+		return nil
+	})
+}
+
+// userStats returns the counts shown by whoami for the logged in user
+func userStats(t *testing.T, s *State) database.GetUserStatsRow {
+	t.Helper()
+	stats, err := s.DB.GetUserStats(context.Background(), loggedInUser(t, s).ID)
+	if err != nil {
+		t.Fatalf("failed to get user stats: %v", err)
+	}
+	return stats
+}
+
+// assertContains fails the test when out doesn't contain every one of want
+func assertContains(t *testing.T, out string, want ...string) {
+	t.Helper()
+	for _, w := range want {
+		if !strings.Contains(out, w) {
+			t.Errorf("output does not contain %q:\n%s", w, out)
+		}
+	}
+}
+
+// assertNotContains fails the test when out contains any of unwanted
+func assertNotContains(t *testing.T, out string, unwanted ...string) {
+	t.Helper()
+	for _, u := range unwanted {
+		if strings.Contains(out, u) {
+			t.Errorf("output contains %q:\n%s", u, out)
+		}
+	}
+}
@@ -26,4 +26,48 @@ func (c *Commands) Run(s *State, cmd Command) error {
 // Register registers a new handler function for a command name
 func (c *Commands) Register(name string, f func(*State, Command) error) {
 	c.handlers[name] = f
-}
\ No newline at end of file
+}
+
+// RegisterCommands registers every command of the CLI with the middleware it needs
+func RegisterCommands(c *Commands) {
+	c.Register("init", HandlerInit)
+	c.Register("profile", HandlerProfile)
+	c.Register("migrate", HandlerMigrate)
+	c.Register("login", HandlerLogin)
+	c.Register("register", HandlerRegister)
+	c.Register("logout", HandlerLogout)
+	c.Register("passwd", MiddlewareLoggedIn(HandlerPasswd))
+	c.Register("reset", MiddlewareAdmin(HandlerReset))
+	c.Register("backup", MiddlewareAdmin(HandlerBackup))
+	c.Register("restore", HandlerRestore)
+	c.Register("users", MiddlewareAdmin(HandlerUsers))
+	c.Register("promote", MiddlewareAdmin(HandlerPromote))
+	c.Register("demote", MiddlewareAdmin(HandlerDemote))
+	c.Register("deleteuser", MiddlewareLoggedIn(HandlerDeleteUser))
+	c.Register("renameuser", MiddlewareLoggedIn(HandlerRenameUser))
+	c.Register("whoami", MiddlewareLoggedIn(HandlerWhoami))
+	c.Register("timezone", MiddlewareLoggedIn(HandlerTimezone))
+	c.Register("agg", HandlerAgg)
+	c.Register("prune", MiddlewareAdmin(HandlerPrune))
+	c.Register("addfeed", MiddlewareMember(HandlerAddFeed))
+	c.Register("feeds", HandlerFeeds)
+	c.Register("editfeed", MiddlewareMember(HandlerEditFeed))
+	c.Register("deletefeed", MiddlewareMember(HandlerDeleteFeed))
+	c.Register("mergefeeds", MiddlewareMember(HandlerMergeFeeds))
+	c.Register("icon", HandlerIcon)
+	c.Register("follow", MiddlewareLoggedIn(HandlerFollow))
+	c.Register("following", MiddlewareLoggedIn(HandlerFollowing))
+	c.Register("follow-settings", MiddlewareLoggedIn(HandlerFollowSettings))
+	c.Register("unfollow", MiddlewareLoggedIn(HandlerUnfollow))
+	c.Register("tag", MiddlewareLoggedIn(HandlerTag))
+	c.Register("folders", MiddlewareLoggedIn(HandlerFolders))
+	c.Register("browse", MiddlewareLoggedIn(HandlerBrowse))
+	c.Register("markread", MiddlewareLoggedIn(HandlerMarkRead))
+	c.Register("star", MiddlewareLoggedIn(HandlerStar))
+	c.Register("unstar", MiddlewareLoggedIn(HandlerUnstar))
+	c.Register("starred", MiddlewareLoggedIn(HandlerStarred))
+	c.Register("search", MiddlewareLoggedIn(HandlerSearch))
+	c.Register("rules", MiddlewareLoggedIn(HandlerRules))
+	c.Register("import", MiddlewareMember(HandlerImport))
+	c.Register("export", HandlerExport)
+}
@@ -0,0 +1,427 @@
+package cli
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/PassZ/rss-aggregator/internal/database"
+	"github.com/PassZ/rss-aggregator/internal/feedurl"
+)
+
+// HandlerEditFeed handles the editfeed command
+func HandlerEditFeed(s *State, cmd Command, user database.User) error {
+	fs := newFlagSet(cmd)
+	name := fs.String("name", "", "new name for the feed")
+	newURL := fs.String("url", "", "new URL for the feed")
+	pause := fs.Bool("pause", false, "stop the aggregator from fetching the feed")
+	resume := fs.Bool("resume", false, "let the aggregator fetch the feed again")
+	maxAgeDays := fs.Int("max-age-days", 0, "prune posts older than this many days, 0 keeps them forever")
+	maxPosts := fs.Int("max-posts", 0, "keep only this many of the newest posts, 0 keeps them all")
+	defaultRetention := fs.Bool("default-retention", false, "go back to the global retention policy")
+	args, err := parseFlags(fs, cmd.Args)
+	if err != nil {
+		return err
+	}
+	set := flagsSet(fs)
+
+	// Check if the command has the required arguments
+	if len(args) == 0 {
+		return fmt.Errorf("usage: editfeed <url> [--name name] [--url url] [--pause|--resume] [--max-age-days n] [--max-posts n] [--default-retention]")
+	}
+	if *pause && *resume {
+		return fmt.Errorf("--pause and --resume cannot be combined")
+	}
+	if *defaultRetention && (set["max-age-days"] || set["max-posts"]) {
+		return fmt.Errorf("--default-retention cannot be combined with --max-age-days or --max-posts")
+	}
+	if *maxAgeDays < 0 || *maxPosts < 0 {
+		return fmt.Errorf("retention limits cannot be negative")
+	}
+	if len(set) == 0 {
+		return fmt.Errorf("nothing to change, use --name, --url, --pause, --resume or the retention flags")
+	}
+
+	feed, err := getManagedFeed(s, user, args[0])
+	if err != nil {
+		return err
+	}
+
+	// Apply the requested changes on top of the current values
+	params := database.UpdateFeedParams{
+		ID:     feed.ID,
+		Name:   feed.Name,
+		Url:    feed.Url,
+		UrlKey: feed.UrlKey,
+		Paused: feed.Paused,
+	}
+	if *name != "" {
+		params.Name = *name
+	}
+	if *newURL != "" {
+		params.Url, err = feedurl.Normalize(*newURL)
+		if err != nil {
+			return err
+		}
+		urlKey, err := feedurl.Key(params.Url)
+		if err != nil {
+			return err
+		}
+		params.UrlKey = sql.NullString{String: urlKey, Valid: true}
+
+		// Make sure no other feed already uses an equivalent URL
+		other, err := findFeedByURL(s, params.Url)
+		if err == nil && other.ID != feed.ID {
+			return fmt.Errorf("feed '%s' already uses URL '%s'", other.Name, params.Url)
+		}
+		if err != nil && err != sql.ErrNoRows {
+			return fmt.Errorf("failed to check feed URL: %w", err)
+		}
+	}
+	if *pause {
+		params.Paused = true
+	}
+	if *resume {
+		params.Paused = false
+	}
+
+	// Update the feed and its retention together so a failure never applies half the changes
+	tx, err := s.DB.BeginTx(context.Background(), nil)
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	updated, err := tx.UpdateFeed(context.Background(), params)
+	if err != nil {
+		return fmt.Errorf("failed to update feed: %w", err)
+	}
+
+	// Retention flags only change the limits they name
+	if set["max-age-days"] || set["max-posts"] || *defaultRetention {
+		retention := database.SetFeedRetentionParams{
+			ID:                  feed.ID,
+			RetentionMaxAgeDays: feed.RetentionMaxAgeDays,
+			RetentionMaxPosts:   feed.RetentionMaxPosts,
+		}
+		if set["max-age-days"] {
+			retention.RetentionMaxAgeDays = sql.NullInt32{Int32: int32(*maxAgeDays), Valid: true}
+		}
+		if set["max-posts"] {
+			retention.RetentionMaxPosts = sql.NullInt32{Int32: int32(*maxPosts), Valid: true}
+		}
+		if *defaultRetention {
+			retention.RetentionMaxAgeDays = sql.NullInt32{}
+			retention.RetentionMaxPosts = sql.NullInt32{}
+		}
+		updated, err = tx.SetFeedRetention(context.Background(), retention)
+		if err != nil {
+			return fmt.Errorf("failed to update retention: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit feed changes: %w", err)
+	}
+
+	// Print the updated feed
+	fmt.Printf("Feed updated successfully:\n")
+	fmt.Printf("  Name: %s\n", updated.Name)
+	fmt.Printf("  URL: %s\n", updated.Url)
+	if updated.Paused {
+		fmt.Printf("  Status: paused\n")
+	} else {
+		fmt.Printf("  Status: active\n")
+	}
+	fmt.Printf("  Retention: %s\n", describeRetention(updated))
+	return nil
+}
+
+// describeRetention summarizes a feed's retention policy
+func describeRetention(feed database.Feed) string {
+	return fmt.Sprintf("max age %s, max posts %s",
+		describeLimit(feed.RetentionMaxAgeDays, "day(s)"), describeLimit(feed.RetentionMaxPosts, "post(s)"))
+}
+
+// describeLimit describes a single retention limit, where NULL means the global policy
+func describeLimit(limit sql.NullInt32, unit string) string {
+	if !limit.Valid {
+		return "from global policy"
+	}
+	if limit.Int32 == 0 {
+		return "unlimited"
+	}
+	return fmt.Sprintf("%d %s", limit.Int32, unit)
+}
+
+// HandlerDeleteFeed handles the deletefeed command
+func HandlerDeleteFeed(s *State, cmd Command, user database.User) error {
+	fs := newFlagSet(cmd)
+	yes := fs.Bool("yes", false, "delete or transfer without asking for confirmation")
+	transferTo := fs.String("transfer-to", "", "give the feed to another user instead of deleting it")
+	args, err := parseFlags(fs, cmd.Args)
+	if err != nil {
+		return err
+	}
+
+	// Check if the command has the required argument
+	if len(args) == 0 {
+		return fmt.Errorf("usage: deletefeed <url> [--yes] [--transfer-to user]")
+	}
+
+	feed, err := getManagedFeed(s, user, args[0])
+	if err != nil {
+		return err
+	}
+
+	// Hand the feed over to another user instead of deleting it
+	if *transferTo != "" {
+		newOwner, err := s.DB.GetUser(context.Background(), *transferTo)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return fmt.Errorf("user '%s' does not exist", *transferTo)
+			}
+			return fmt.Errorf("failed to get user: %w", err)
+		}
+
+		if !*yes {
+			question := fmt.Sprintf("Give feed '%s' to %s?", feed.Name, newOwner.Name)
+			ok, err := confirm(question)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				fmt.Println("Aborted, nothing was transferred.")
+				return nil
+			}
+		}
+
+		err = s.DB.TransferFeed(context.Background(), database.TransferFeedParams{
+			ID:     feed.ID,
+			UserID: newOwner.ID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to transfer feed: %w", err)
+		}
+
+		fmt.Printf("Feed %s now belongs to %s\n", feed.Name, newOwner.Name)
+		return nil
+	}
+
+	// Show what will be removed along with the feed and ask for confirmation
+	stats, err := s.DB.GetFeedStats(context.Background(), feed.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get feed stats: %w", err)
+	}
+	if !*yes {
+		question := fmt.Sprintf("Delete feed '%s' along with %d follow(s) and %d post(s)?",
+			feed.Name, stats.FollowCount, stats.PostCount)
+		ok, err := confirm(question)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			fmt.Println("Aborted, nothing was deleted.")
+			return nil
+		}
+	}
+
+	if err := autoBackup(s, "deletefeed"); err != nil {
+		return fmt.Errorf("backup failed, nothing was deleted: %w", err)
+	}
+
+	// Follows and posts are removed by the ON DELETE CASCADE constraints
+	err = s.DB.DeleteFeed(context.Background(), feed.ID)
+	if err != nil {
+		return fmt.Errorf("failed to delete feed: %w", err)
+	}
+
+	fmt.Printf("Deleted feed %s (%d follow(s) and %d post(s) removed)\n",
+		feed.Name, stats.FollowCount, stats.PostCount)
+	return nil
+}
+
+// HandlerMergeFeeds handles the mergefeeds command
+func HandlerMergeFeeds(s *State, cmd Command, user database.User) error {
+	fs := newFlagSet(cmd)
+	yes := fs.Bool("yes", false, "merge without asking for confirmation")
+	args, err := parseFlags(fs, cmd.Args)
+	if err != nil {
+		return err
+	}
+
+	// Without arguments, list the feeds that look like duplicates of each other
+	if len(args) == 0 {
+		return printDuplicateFeeds(s)
+	}
+	if len(args) < 2 {
+		return fmt.Errorf("usage: mergefeeds [<keep-url> <duplicate-url>...] [--yes]")
+	}
+
+	// Look up the feed to keep and the duplicates to fold into it
+	target, err := findFeedByURL(s, args[0])
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("feed with URL '%s' not found", args[0])
+		}
+		return fmt.Errorf("failed to get feed: %w", err)
+	}
+	var duplicates []database.Feed
+	for _, duplicateURL := range args[1:] {
+		duplicate, err := getManagedFeed(s, user, duplicateURL)
+		if err != nil {
+			return err
+		}
+		if duplicate.ID == target.ID {
+			return fmt.Errorf("cannot merge feed '%s' into itself", target.Name)
+		}
+		duplicates = append(duplicates, duplicate)
+	}
+
+	if !*yes {
+		question := fmt.Sprintf("Merge %d feed(s) into '%s'? Their follows and posts will be moved and the duplicates deleted.",
+			len(duplicates), target.Name)
+		ok, err := confirm(question)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			fmt.Println("Aborted, nothing was merged.")
+			return nil
+		}
+	}
+
+	// Merge everything in one transaction so a failure never leaves follows and posts
+	// split across the feeds
+	tx, err := s.DB.BeginTx(context.Background(), nil)
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var merged []string
+	for _, duplicate := range duplicates {
+		follows, err := tx.MoveFeedFollows(context.Background(), database.MoveFeedFollowsParams{
+			ToFeedID:   target.ID,
+			FromFeedID: duplicate.ID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to move follows of '%s': %w", duplicate.Name, err)
+		}
+		err = tx.MoveFeedFollowTags(context.Background(), database.MoveFeedFollowTagsParams{
+			ToFeedID:   target.ID,
+			FromFeedID: duplicate.ID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to move tags of '%s': %w", duplicate.Name, err)
+		}
+
+		posts, err := tx.MovePosts(context.Background(), database.MovePostsParams{
+			ToFeedID:   target.ID,
+			FromFeedID: duplicate.ID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to move posts of '%s': %w", duplicate.Name, err)
+		}
+		err = tx.MovePostTombstones(context.Background(), database.MovePostTombstonesParams{
+			ToFeedID:   target.ID,
+			FromFeedID: duplicate.ID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to move pruned posts of '%s': %w", duplicate.Name, err)
+		}
+
+		err = tx.DeleteFeed(context.Background(), duplicate.ID)
+		if err != nil {
+			return fmt.Errorf("failed to delete feed '%s': %w", duplicate.Name, err)
+		}
+
+		merged = append(merged, fmt.Sprintf("Merged %s into %s (%d follow(s) and %d post(s) moved)",
+			duplicate.Url, target.Name, follows, posts))
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit merge: %w", err)
+	}
+	for _, line := range merged {
+		fmt.Println(line)
+	}
+	return nil
+}
+
+// printDuplicateFeeds lists groups of feeds with equivalent URLs or identical content
+func printDuplicateFeeds(s *State) error {
+	feeds, err := s.DB.GetFeeds(context.Background())
+	if err != nil {
+		return fmt.Errorf("failed to get feeds: %w", err)
+	}
+
+	// Group feeds by URL key and by content hash
+	byKey := make(map[string][]database.GetFeedsRow)
+	byContent := make(map[string][]database.GetFeedsRow)
+	for _, feed := range feeds {
+		if urlKey, err := feedurl.Key(feed.Url); err == nil {
+			byKey[urlKey] = append(byKey[urlKey], feed)
+		}
+		if feed.ContentHash.Valid {
+			byContent[feed.ContentHash.String] = append(byContent[feed.ContentHash.String], feed)
+		}
+	}
+
+	found := printFeedGroups("Feeds with equivalent URLs:", byKey)
+	found = printFeedGroups("Feeds with identical content:", byContent) || found
+	if !found {
+		fmt.Println("No duplicate feeds found.")
+	}
+	return nil
+}
+
+// printFeedGroups prints the groups holding more than one feed, suggesting to keep the oldest
+func printFeedGroups(heading string, groups map[string][]database.GetFeedsRow) bool {
+	var keys []string
+	for key, group := range groups {
+		if len(group) > 1 {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		return false
+	}
+	sort.Strings(keys)
+
+	fmt.Println(heading)
+	for _, key := range keys {
+		group := groups[key]
+		// Feeds are listed newest first, so the oldest is last
+		sort.SliceStable(group, func(i, j int) bool {
+			return group[i].CreatedAt.Before(group[j].CreatedAt)
+		})
+
+		urls := make([]string, len(group))
+		for i, feed := range group {
+			fmt.Printf("  - %s (%s, added by %s)\n", feed.Name, feed.Url, feed.UserName)
+			urls[i] = feed.Url
+		}
+		fmt.Printf("    Merge with: gator mergefeeds %s\n\n", strings.Join(urls, " "))
+	}
+	return true
+}
+
+// getManagedFeed looks up a feed by URL and checks that the user is allowed to change it
+func getManagedFeed(s *State, user database.User, feedURL string) (database.Feed, error) {
+	feed, err := findFeedByURL(s, feedURL)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return database.Feed{}, fmt.Errorf("feed with URL '%s' not found", feedURL)
+		}
+		return database.Feed{}, fmt.Errorf("failed to get feed: %w", err)
+	}
+
+	if feed.UserID != user.ID && user.Role != roleAdmin {
+		return database.Feed{}, fmt.Errorf("only the user who added feed '%s' or an admin can change it", feed.Name)
+	}
+
+	return feed, nil
+}
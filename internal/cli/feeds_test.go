@@ -0,0 +1,139 @@
+package cli
+
+import (
+	"context"
+	"testing"
+)
+
+func TestEditFeed(t *testing.T) {
+	s, server := newTestState(t)
+	registerUser(t, s, "alice")
+	alphaURL, betaURL := server.URL+"/alpha.xml", server.URL+"/beta.xml"
+	mustRun(t, s, "addfeed", alphaURL)
+	mustRun(t, s, "addfeed", betaURL)
+
+	if _, err := run(t, s, "editfeed", alphaURL); err == nil {
+		t.Error("editfeed without changes succeeded")
+	}
+	if _, err := run(t, s, "editfeed", alphaURL, "--pause", "--resume"); err == nil {
+		t.Error("editfeed with --pause and --resume succeeded")
+	}
+
+	out := mustRun(t, s, "editfeed", alphaURL, "--name", "Alpha", "--pause", "--max-posts", "5")
+	assertContains(t, out, "Name: Alpha", "Status: paused", "max posts 5 post(s)")
+	feed := getFeed(t, s, alphaURL)
+	if feed.Name != "Alpha" || !feed.Paused || feed.RetentionMaxPosts.Int32 != 5 || feed.RetentionMaxAgeDays.Valid {
+		t.Errorf("got feed %+v after editing", feed)
+	}
+
+	// Paused feeds are not fetched
+	aggregate(t, s)
+	out = mustRun(t, s, "browse")
+	assertNotContains(t, out, "Postgres performance")
+
+	mustRun(t, s, "editfeed", alphaURL, "--resume", "--default-retention")
+	if feed := getFeed(t, s, alphaURL); feed.Paused || feed.RetentionMaxPosts.Valid {
+		t.Errorf("got feed %+v after resuming it with the default retention", feed)
+	}
+
+	// A new URL may not be another spelling of an existing feed's
+	if _, err := run(t, s, "editfeed", alphaURL, "--url", "  "+betaURL+"#top"); err == nil {
+		t.Error("editfeed gave a feed the URL of another one")
+	}
+	if feed := getFeed(t, s, alphaURL); feed.Name != "Alpha" {
+		t.Errorf("a failed edit changed the feed to %+v", feed)
+	}
+
+	// Only the user who added a feed or an admin may edit it
+	registerUser(t, s, "bob")
+	if _, err := run(t, s, "editfeed", alphaURL, "--name", "Mine"); err == nil {
+		t.Error("a member edited another user's feed")
+	}
+}
+
+func TestDeleteFeed(t *testing.T) {
+	s, server := newTestState(t)
+	setInput(t, "")
+	mustRun(t, s, "register", "alice")
+	alphaURL := server.URL + "/alpha.xml"
+	mustRun(t, s, "addfeed", alphaURL)
+	aggregate(t, s)
+	bob := registerUser(t, s, "bob")
+	mustRun(t, s, "follow", alphaURL)
+	setInput(t, "")
+	mustRun(t, s, "login", "alice")
+
+	// Transfers are confirmed like deletions
+	setInput(t, "n")
+	out := mustRun(t, s, "deletefeed", alphaURL, "--transfer-to", "bob")
+	assertContains(t, out, "Give feed 'Alpha Blog' to bob?", "Aborted")
+	if feed := getFeed(t, s, alphaURL); feed.UserID == bob.ID {
+		t.Fatal("the feed was transferred without confirmation")
+	}
+	setInput(t, "y")
+	mustRun(t, s, "deletefeed", alphaURL, "--transfer-to", "bob")
+	if feed := getFeed(t, s, alphaURL); feed.UserID != bob.ID {
+		t.Fatal("the feed was not transferred")
+	}
+
+	setInput(t, "n")
+	out = mustRun(t, s, "deletefeed", alphaURL)
+	assertContains(t, out, "along with 2 follow(s) and 3 post(s)?", "Aborted")
+	getFeed(t, s, alphaURL)
+	if len(backups(t)) != 0 {
+		t.Error("an aborted deletion wrote a backup")
+	}
+
+	out = mustRun(t, s, "deletefeed", alphaURL, "--yes")
+	assertContains(t, out, "Deleted feed Alpha Blog (2 follow(s) and 3 post(s) removed)")
+	if _, err := findFeedByURL(s, alphaURL); err == nil {
+		t.Error("the feed still exists after deleting it")
+	}
+	if len(backups(t)) != 1 {
+		t.Errorf("got %d backup(s) after deleting a feed, want 1", len(backups(t)))
+	}
+}
+
+func TestMergeFeeds(t *testing.T) {
+	s, server := newTestState(t)
+	registerUser(t, s, "alice")
+	alphaURL, mirrorURL := server.URL+"/alpha.xml", server.URL+"/alpha-mirror.xml"
+	mustRun(t, s, "addfeed", alphaURL)
+	mustRun(t, s, "addfeed", "Alpha Mirror", mirrorURL)
+	registerUser(t, s, "bob")
+	mustRun(t, s, "unfollow", alphaURL)
+	mustRun(t, s, "follow", mirrorURL)
+	mustRun(t, s, "tag", mirrorURL, "databases")
+	setInput(t, "")
+	mustRun(t, s, "login", "alice")
+
+	// Feeds serving the same posts are found once fetched
+	out := mustRun(t, s, "mergefeeds")
+	assertContains(t, out, "No duplicate feeds found.")
+	aggregate(t, s)
+	out = mustRun(t, s, "mergefeeds")
+	assertContains(t, out, "Feeds with identical content:", "gator mergefeeds "+alphaURL+" "+mirrorURL)
+
+	setInput(t, "n")
+	mustRun(t, s, "mergefeeds", alphaURL, mirrorURL)
+	getFeed(t, s, mirrorURL)
+
+	out = mustRun(t, s, "mergefeeds", alphaURL, mirrorURL, "--yes")
+	assertContains(t, out, "Merged "+mirrorURL+" into Alpha Blog")
+	if _, err := findFeedByURL(s, mirrorURL); err == nil {
+		t.Error("the duplicate still exists after merging it")
+	}
+
+	// Bob now follows the feed that was kept, in the same folder
+	setInput(t, "")
+	mustRun(t, s, "login", "bob")
+	follows, err := s.DB.GetFeedFollowsForUser(context.Background(), loggedInUser(t, s).ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(follows) != 1 || follows[0].FeedUrl != alphaURL {
+		t.Fatalf("got follows %+v after merging, want only %s", follows, alphaURL)
+	}
+	out = mustRun(t, s, "browse", "--folder", "databases")
+	assertContains(t, out, "Postgres performance")
+}
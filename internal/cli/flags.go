@@ -0,0 +1,41 @@
+package cli
+
+import "flag"
+
+// newFlagSet creates a flag set for a command that reports errors instead of exiting
+func newFlagSet(cmd Command) *flag.FlagSet {
+	return flag.NewFlagSet(cmd.Name, flag.ContinueOnError)
+}
+
+// parseFlags parses flags that may appear before, between or after the positional
+// arguments, returning the positional arguments in order
+func parseFlags(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+
+		// Everything after a "--" terminator is positional
+		consumed := len(args) - fs.NArg()
+		if consumed > 0 && args[consumed-1] == "--" {
+			return append(positional, fs.Args()...), nil
+		}
+
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+// flagsSet returns the names of the flags that were given on the command line
+func flagsSet(fs *flag.FlagSet) map[string]bool {
+	set := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) {
+		set[f.Name] = true
+	})
+	return set
+}
\ No newline at end of file
@@ -0,0 +1,101 @@
+package cli
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/PassZ/rss-aggregator/internal/database"
+)
+
+// HandlerFollowSettings handles the follow-settings command
+func HandlerFollowSettings(s *State, cmd Command, user database.User) error {
+	fs := newFlagSet(cmd)
+	title := fs.String("title", "", "show the feed under this title instead of its name")
+	clearTitle := fs.Bool("clear-title", false, "go back to the feed's own name")
+	mute := fs.Bool("mute", false, "hide the feed's posts from browse, it is still fetched")
+	unmute := fs.Bool("unmute", false, "show the feed's posts in browse again")
+	priority := fs.Int("priority", 0, "sort the feed higher in following, higher comes first")
+	notify := fs.Bool("notify", false, "announce new posts of the feed while the aggregator runs")
+	noNotify := fs.Bool("no-notify", false, "stop announcing new posts of the feed")
+	args, err := parseFlags(fs, cmd.Args)
+	if err != nil {
+		return err
+	}
+
+	// Check if the command has the required argument
+	if len(args) == 0 {
+		return fmt.Errorf("usage: follow-settings <url> [--title title | --clear-title] [--mute|--unmute] [--priority n] [--notify|--no-notify]")
+	}
+	if *title != "" && *clearTitle {
+		return fmt.Errorf("--title and --clear-title cannot be combined")
+	}
+	if *mute && *unmute {
+		return fmt.Errorf("--mute and --unmute cannot be combined")
+	}
+	if *notify && *noNotify {
+		return fmt.Errorf("--notify and --no-notify cannot be combined")
+	}
+
+	follow, feed, err := getFollow(s, user, args[0])
+	if err != nil {
+		return err
+	}
+
+	// Apply the requested changes on top of the current settings
+	params := database.UpdateFeedFollowSettingsParams{
+		ID:          follow.ID,
+		CustomTitle: follow.CustomTitle,
+		Muted:       follow.Muted,
+		Priority:    follow.Priority,
+		Notify:      follow.Notify,
+	}
+	set := flagsSet(fs)
+	if *title != "" {
+		params.CustomTitle = nullString(*title)
+	}
+	if *clearTitle {
+		params.CustomTitle = sql.NullString{}
+	}
+	if *mute || *unmute {
+		params.Muted = *mute
+	}
+	if set["priority"] {
+		params.Priority = int32(*priority)
+	}
+	if *notify || *noNotify {
+		params.Notify = *notify
+	}
+
+	// Without changes, just show the current settings
+	if len(set) > 0 {
+		follow, err = s.DB.UpdateFeedFollowSettings(context.Background(), params)
+		if err != nil {
+			return fmt.Errorf("failed to update follow settings: %w", err)
+		}
+		fmt.Printf("Follow settings updated:\n")
+	} else {
+		fmt.Printf("Follow settings:\n")
+	}
+
+	fmt.Printf("  Feed: %s\n", feed.Name)
+	if follow.CustomTitle.Valid {
+		fmt.Printf("  Title: %s\n", follow.CustomTitle.String)
+	}
+	fmt.Printf("  Muted: %t\n", follow.Muted)
+	fmt.Printf("  Priority: %d\n", follow.Priority)
+	fmt.Printf("  Notify: %t\n", follow.Notify)
+	return nil
+}
+
+// followMarkers returns the muted and notify markers shown after a followed feed's name
+func followMarkers(muted, notify bool) string {
+	var markers string
+	if muted {
+		markers += " [muted]"
+	}
+	if notify {
+		markers += " [notify]"
+	}
+	return markers
+}
\ No newline at end of file
@@ -0,0 +1,47 @@
+package cli
+
+import "testing"
+
+func TestFollowSettingsCustomTitle(t *testing.T) {
+	s, server := newTestState(t)
+	setInput(t, "")
+	mustRun(t, s, "register", "alice")
+	alphaURL := server.URL + "/alpha.xml"
+	mustRun(t, s, "addfeed", alphaURL)
+	registerUser(t, s, "bob")
+	mustRun(t, s, "follow", alphaURL)
+
+	out := mustRun(t, s, "follow-settings", alphaURL, "--title", "Laptop DBs")
+	assertContains(t, out, "Title: Laptop DBs")
+
+	// Rules see the feed under the follower's own title, as they do when applied later
+	mustRun(t, s, "rules", "add", "laptop", "--field", "feed", "--action", "star")
+	aggregate(t, s)
+	if stats := userStats(t, s); stats.StarCount != 3 {
+		t.Errorf("got %d starred post(s), want all 3 posts of the renamed feed", stats.StarCount)
+	}
+	out = mustRun(t, s, "rules", "test", "laptop", "--field", "feed")
+	assertContains(t, out, "3 of 3 post(s) match", "Feed: Laptop DBs")
+
+	// and so do browse and search
+	out = mustRun(t, s, "browse")
+	assertContains(t, out, "Laptop DBs")
+	out = mustRun(t, s, "search", "postgres")
+	assertContains(t, out, "Feed: Laptop DBs")
+	assertNotContains(t, out, "Alpha Blog")
+
+	// Other followers keep the feed's own name
+	setInput(t, "")
+	mustRun(t, s, "login", "alice")
+	out = mustRun(t, s, "search", "postgres")
+	assertContains(t, out, "Feed: Alpha Blog")
+	if stats := userStats(t, s); stats.StarCount != 0 {
+		t.Errorf("bob's rule starred %d post(s) for alice", stats.StarCount)
+	}
+
+	setInput(t, "")
+	mustRun(t, s, "login", "bob")
+	mustRun(t, s, "follow-settings", alphaURL, "--clear-title")
+	out = mustRun(t, s, "search", "postgres")
+	assertContains(t, out, "Feed: Alpha Blog")
+}
@@ -2,27 +2,39 @@ package cli
 
 import (
 	"context"
+	"crypto/sha256"
 	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"math"
+	"os"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/PassZ/rss-aggregator/internal/auth"
 	"github.com/PassZ/rss-aggregator/internal/database"
+	"github.com/PassZ/rss-aggregator/internal/feedurl"
 	"github.com/PassZ/rss-aggregator/internal/rss"
+	"github.com/PassZ/rss-aggregator/internal/rules"
+	"github.com/PassZ/rss-aggregator/internal/store"
 )
 
+// iconRefreshInterval is how long a stored feed icon is used before it is checked again
+const iconRefreshInterval = 24 * time.Hour
+
+// pruneInterval is how often the aggregator applies the retention policy
+const pruneInterval = time.Hour
+
 // MiddlewareLoggedIn is a higher-order function that wraps handlers requiring authentication
 func MiddlewareLoggedIn(handler func(s *State, cmd Command, user database.User) error) func(*State, Command) error {
 	return func(s *State, cmd Command) error {
-		// Get the current user from the database
-		currentUser := s.Config.CurrentUserName
-		user, err := s.DB.GetUser(context.Background(), currentUser)
+		// Get the user of the current session from the database
+		user, err := currentUser(s)
 		if err != nil {
-			if err == sql.ErrNoRows {
-				return fmt.Errorf("current user '%s' not found in database", currentUser)
-			}
-			return fmt.Errorf("failed to get current user: %w", err)
+			return err
 		}
 
 		// Call the wrapped handler with the user
@@ -30,6 +42,26 @@ func MiddlewareLoggedIn(handler func(s *State, cmd Command, user database.User)
 	}
 }
 
+// MiddlewareMember wraps handlers that change shared data, which read-only users may not do
+func MiddlewareMember(handler func(s *State, cmd Command, user database.User) error) func(*State, Command) error {
+	return MiddlewareLoggedIn(func(s *State, cmd Command, user database.User) error {
+		if user.Role == roleReadOnly {
+			return fmt.Errorf("read-only users cannot use the %s command", cmd.Name)
+		}
+		return handler(s, cmd, user)
+	})
+}
+
+// MiddlewareAdmin wraps handlers that only admins may use
+func MiddlewareAdmin(handler func(s *State, cmd Command, user database.User) error) func(*State, Command) error {
+	return MiddlewareLoggedIn(func(s *State, cmd Command, user database.User) error {
+		if user.Role != roleAdmin {
+			return fmt.Errorf("only admins can use the %s command", cmd.Name)
+		}
+		return handler(s, cmd, user)
+	})
+}
+
 // HandlerLogin handles the login command
 func HandlerLogin(s *State, cmd Command) error {
 	// Check if the command has the required argument
@@ -38,10 +70,13 @@ func HandlerLogin(s *State, cmd Command) error {
 	}
 
 	// Get the username from the first argument
-	username := cmd.Args[0]
+	username, err := normalizeUsername(cmd.Args[0])
+	if err != nil {
+		return err
+	}
 
 	// Check if user exists in database
-	_, err := s.DB.GetUser(context.Background(), username)
+	user, err := s.DB.GetUser(context.Background(), username)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return fmt.Errorf("user '%s' does not exist", username)
@@ -49,9 +84,20 @@ func HandlerLogin(s *State, cmd Command) error {
 		return fmt.Errorf("failed to check user: %w", err)
 	}
 
-	// Set the user in the config
-	if err := s.Config.SetUser(username); err != nil {
-		return fmt.Errorf("failed to set user: %w", err)
+	// Users with a password have to give it
+	if user.PasswordHash.Valid {
+		password, err := readPassword("Password: ")
+		if err != nil {
+			return err
+		}
+		if err := auth.CheckPassword(user.PasswordHash.String, password); err != nil {
+			return err
+		}
+	}
+
+	// Start a session and remember it in the config
+	if err := startSession(s, user); err != nil {
+		return err
 	}
 
 	// Print success message
@@ -67,10 +113,13 @@ func HandlerRegister(s *State, cmd Command) error {
 	}
 
 	// Get the username from the first argument
-	username := cmd.Args[0]
+	username, err := normalizeUsername(cmd.Args[0])
+	if err != nil {
+		return err
+	}
 
 	// Check if user already exists
-	_, err := s.DB.GetUser(context.Background(), username)
+	_, err = s.DB.GetUser(context.Background(), username)
 	if err == nil {
 		return fmt.Errorf("user '%s' already exists", username)
 	}
@@ -78,21 +127,28 @@ func HandlerRegister(s *State, cmd Command) error {
 		return fmt.Errorf("failed to check user: %w", err)
 	}
 
+	// Passwords are optional
+	passwordHash, err := promptNewPassword()
+	if err != nil {
+		return err
+	}
+
 	// Create new user
-	now := time.Now()
+	now := time.Now().UTC()
 	user, err := s.DB.CreateUser(context.Background(), database.CreateUserParams{
-		ID:        uuid.New(),
-		CreatedAt: now,
-		UpdatedAt: now,
-		Name:      username,
+		ID:           uuid.New(),
+		CreatedAt:    now,
+		UpdatedAt:    now,
+		Name:         username,
+		PasswordHash: passwordHash,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to create user: %w", err)
 	}
 
-	// Set the user in the config
-	if err := s.Config.SetUser(username); err != nil {
-		return fmt.Errorf("failed to set user: %w", err)
+	// Start a session and remember it in the config
+	if err := startSession(s, user); err != nil {
+		return err
 	}
 
 	// Print success message and user data
@@ -103,35 +159,135 @@ func HandlerRegister(s *State, cmd Command) error {
 }
 
 // HandlerReset handles the reset command
-func HandlerReset(s *State, cmd Command) error {
-	// Delete all users from the database
-	err := s.DB.DeleteAllUsers(context.Background())
+func HandlerReset(s *State, cmd Command, user database.User) error {
+	fs := newFlagSet(cmd)
+	yes := fs.Bool("yes", false, "reset without asking for confirmation")
+	posts := fs.Bool("posts", false, "only delete posts, keeping users, feeds and follows")
+	feeds := fs.Bool("feeds", false, "only delete feeds along with their follows and posts, keeping users")
+	userName := fs.String("user", "", "only clear this user's follows, reads, stars, rules and tags, keeping the account")
+	if _, err := parseFlags(fs, cmd.Args); err != nil {
+		return err
+	}
+
+	// Work out what will be deleted, only one scope can be picked
+	scopes := 0
+	for _, set := range []bool{*posts, *feeds, *userName != ""} {
+		if set {
+			scopes++
+		}
+	}
+	if scopes > 1 {
+		return fmt.Errorf("--posts, --feeds and --user cannot be combined")
+	}
+
+	var target database.User
+	question := "Delete ALL users, feeds and posts?"
+	switch {
+	case *posts:
+		question = "Delete all posts along with their read, star and tag state?"
+	case *feeds:
+		question = "Delete all feeds along with their follows and posts?"
+	case *userName != "":
+		var err error
+		target, err = s.DB.GetUser(context.Background(), *userName)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return fmt.Errorf("user '%s' does not exist", *userName)
+			}
+			return fmt.Errorf("failed to get user: %w", err)
+		}
+		question = fmt.Sprintf("Delete all follows, reads, stars, rules and tags of %s?", target.Name)
+	}
+
+	if !*yes {
+		ok, err := confirm(question)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			fmt.Println("Aborted, nothing was deleted.")
+			return nil
+		}
+	}
+
+	// Keep a copy of everything so a mistaken reset can be undone
+	if err := autoBackup(s, "reset"); err != nil {
+		return fmt.Errorf("backup failed, nothing was deleted: %w", err)
+	}
+
+	switch {
+	case *posts:
+		// Reads, stars, hides and tags go through ON DELETE CASCADE
+		if err := s.DB.DeleteAllPosts(context.Background()); err != nil {
+			return fmt.Errorf("failed to delete posts: %w", err)
+		}
+		fmt.Println("Database reset successfully - all posts deleted")
+	case *feeds:
+		if err := s.DB.DeleteAllFeeds(context.Background()); err != nil {
+			return fmt.Errorf("failed to delete feeds: %w", err)
+		}
+		fmt.Println("Database reset successfully - all feeds deleted")
+	case *userName != "":
+		if err := resetUser(s, target); err != nil {
+			return err
+		}
+		fmt.Printf("Database reset successfully - %s starts from scratch\n", target.Name)
+	default:
+		// Delete all users from the database, everything else belongs to them
+		if err := s.DB.DeleteAllUsers(context.Background()); err != nil {
+			return fmt.Errorf("failed to reset database: %w", err)
+		}
+		fmt.Println("Database reset successfully - all users deleted")
+	}
+	return nil
+}
+
+// resetUser clears a user's personal state while keeping the account and the feeds they added.
+// Everything is deleted in one transaction so a failure never leaves the user half reset.
+func resetUser(s *State, user database.User) error {
+	tx, err := s.DB.BeginTx(context.Background(), nil)
 	if err != nil {
-		return fmt.Errorf("failed to reset database: %w", err)
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	steps := []struct {
+		what   string
+		delete func(context.Context, uuid.UUID) error
+	}{
+		{"follows", tx.DeleteUserFeedFollows},
+		{"reads", tx.DeleteUserPostReads},
+		{"stars", tx.DeleteUserPostStars},
+		// Hidden posts go along with the rules that hid them
+		{"rules", tx.DeleteUserRules},
+		{"tags", tx.DeleteUserPostTags},
+	}
+	for _, step := range steps {
+		if err := step.delete(context.Background(), user.ID); err != nil {
+			return fmt.Errorf("failed to delete %s: %w", step.what, err)
+		}
 	}
 
-	// Print success message
-	fmt.Println("Database reset successfully - all users deleted")
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit reset: %w", err)
+	}
 	return nil
 }
 
 // HandlerUsers handles the users command
-func HandlerUsers(s *State, cmd Command) error {
+func HandlerUsers(s *State, cmd Command, current database.User) error {
 	// Get all users from the database
 	users, err := s.DB.GetUsers(context.Background())
 	if err != nil {
 		return fmt.Errorf("failed to get users: %w", err)
 	}
 
-	// Get the current user from config
-	currentUser := s.Config.CurrentUserName
-
-	// Print all users with current user marked
+	// Print all users with their role and the current user marked
 	for _, user := range users {
-		if user.Name == currentUser {
-			fmt.Printf("* %s (current)\n", user.Name)
+		if user.ID == current.ID {
+			fmt.Printf("* %s [%s] (current)\n", user.Name, user.Role)
 		} else {
-			fmt.Printf("* %s\n", user.Name)
+			fmt.Printf("* %s [%s]\n", user.Name, user.Role)
 		}
 	}
 
@@ -158,9 +314,19 @@ func HandlerAgg(s *State, cmd Command) error {
 	ticker := time.NewTicker(timeBetweenRequests)
 	defer ticker.Stop()
 
-	// Run immediately, then on every tick
+	// Run immediately, then on every tick, pruning old posts now and then
+	var lastPrune time.Time
 	for {
 		scrapeFeeds(s)
+		if time.Since(lastPrune) >= pruneInterval {
+			pruned, err := prunePosts(s, false)
+			if err != nil {
+				fmt.Printf("Error pruning posts: %v\n", err)
+			} else if len(pruned) > 0 {
+				fmt.Printf("Pruned %d old post(s)\n", len(pruned))
+			}
+			lastPrune = time.Now()
+		}
 		<-ticker.C
 	}
 }
@@ -188,48 +354,123 @@ func scrapeFeeds(s *State) {
 	fmt.Printf("Fetching feed: %s (%s)\n", feed.Name, feed.Url)
 
 	// Fetch the RSS feed
-	rssFeed, err := rss.FetchFeed(context.Background(), feed.Url)
+	rssFeed, err := s.Fetcher.FetchFeed(context.Background(), feed.Url)
 	if err != nil {
 		fmt.Printf("Error fetching RSS feed %s: %v\n", feed.Url, err)
 		return
 	}
 
+	// Refresh the feed metadata from the channel
+	err = s.DB.UpdateFeedMetadata(context.Background(), feedMetadataParams(feed.ID, rssFeed))
+	if err != nil {
+		fmt.Printf("Error updating feed metadata for %s: %v\n", feed.Url, err)
+	}
+
+	// Remember a fingerprint of the content to spot feeds that are duplicates of each other
+	err = s.DB.SetFeedContentHash(context.Background(), database.SetFeedContentHashParams{
+		ID:          feed.ID,
+		ContentHash: nullString(rss.ContentHash(rssFeed)),
+	})
+	if err != nil {
+		fmt.Printf("Error updating content hash for %s: %v\n", feed.Url, err)
+	}
+
+	// Backfill the URL key of feeds added before URLs were normalized
+	if !feed.UrlKey.Valid {
+		if err := backfillFeedURLKey(s, feed); err != nil {
+			fmt.Printf("Error setting URL key for %s: %v\n", feed.Url, err)
+		}
+	}
+
+	// Refresh the feed icon if it is missing or stale
+	if err := refreshFeedIcon(s, feed, rssFeed); err != nil {
+		fmt.Printf("Error refreshing icon for %s: %v\n", feed.Url, err)
+	}
+
+	// Load the rules of everyone following the feed
+	feedRules, err := s.DB.GetRulesForFeed(context.Background(), feed.ID)
+	if err != nil {
+		fmt.Printf("Error getting rules for %s: %v\n", feed.Url, err)
+	}
+	compiled := compileFeedRules(feedRules)
+
 	// Process each item in the feed
+	var saved []string
 	for _, item := range rssFeed.Channel.Item {
-		err := processPost(s, item, feed.ID)
+		isNew, err := processPost(s, item, feed, compiled)
 		if err != nil {
 			// Log error but continue processing other posts
 			fmt.Printf("Error processing post '%s': %v\n", item.Title, err)
+			continue
+		}
+		if isNew {
+			saved = append(saved, item.Title)
 		}
 	}
 
 	fmt.Printf("Processed %d posts from %s\n", len(rssFeed.Channel.Item), feed.Name)
+
+	// Tell the followers who asked for it about the new posts
+	if len(saved) > 0 {
+		if err := notifyFollowers(s, feed.ID, saved); err != nil {
+			fmt.Printf("Error notifying followers of %s: %v\n", feed.Url, err)
+		}
+	}
 }
 
-// processPost saves a single post to the database
-func processPost(s *State, item rss.RSSItem, feedID uuid.UUID) error {
+// notifyFollowers prints a notification for each user who wants to hear about new posts of a feed
+func notifyFollowers(s *State, feedID uuid.UUID, titles []string) error {
+	followers, err := s.DB.GetFeedFollowersToNotify(context.Background(), feedID)
+	if err != nil {
+		return err
+	}
+
+	for _, follower := range followers {
+		fmt.Printf("Notify %s: %d new post(s) in %s\n", follower.UserName, len(titles), follower.FeedName)
+		for _, title := range titles {
+			fmt.Printf("  - %s\n", title)
+		}
+	}
+	return nil
+}
+
+// processPost saves a single post to the database and runs the followers' rules on it,
+// reporting whether it was new
+func processPost(s *State, item rss.RSSItem, feed database.Feed, feedRules []compiledRule) (bool, error) {
 	// Parse published date
 	var publishedAt sql.NullTime
 	if item.PubDate != "" {
 		// Try different date formats
 		formats := []string{
-			time.RFC1123Z,     // Mon, 02 Jan 2006 15:04:05 -0700
-			time.RFC1123,      // Mon, 02 Jan 2006 15:04:05 MST
-			time.RFC3339,      // 2006-01-02T15:04:05Z07:00
+			time.RFC1123Z,                  // Mon, 02 Jan 2006 15:04:05 -0700
+			time.RFC1123,                   // Mon, 02 Jan 2006 15:04:05 MST
+			time.RFC3339,                   // 2006-01-02T15:04:05Z07:00
 			"Mon, 2 Jan 2006 15:04:05 MST", // Alternative format
 		}
 
 		for _, format := range formats {
 			if parsed, err := time.Parse(format, item.PubDate); err == nil {
-				publishedAt = sql.NullTime{Time: parsed, Valid: true}
+				publishedAt = sql.NullTime{Time: parsed.UTC(), Valid: true}
 				break
 			}
 		}
 	}
 
+	// Skip items that were pruned before, they would only be pruned again
+	pruned, err := s.DB.IsPostTombstoned(context.Background(), database.IsPostTombstonedParams{
+		FeedID: feed.ID,
+		Guid:   item.Key(),
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to check pruned posts: %w", err)
+	}
+	if pruned {
+		return false, nil
+	}
+
 	// Create post
-	now := time.Now()
-	_, err := s.DB.CreatePost(context.Background(), database.CreatePostParams{
+	now := time.Now().UTC()
+	post, err := s.DB.CreatePost(context.Background(), database.CreatePostParams{
 		ID:          uuid.New(),
 		CreatedAt:   now,
 		UpdatedAt:   now,
@@ -237,49 +478,278 @@ func processPost(s *State, item rss.RSSItem, feedID uuid.UUID) error {
 		Url:         item.Link,
 		Description: sql.NullString{String: item.Description, Valid: item.Description != ""},
 		PublishedAt: publishedAt,
-		FeedID:      feedID,
+		FeedID:      feed.ID,
+		Content:     sql.NullString{String: item.Content, Valid: item.Content != ""},
+		Author:      nullString(item.AuthorName()),
+		Categories:  nullString(rules.JoinCategories(item.Categories)),
+		Guid:        nullString(item.GUID),
 	})
 
 	if err != nil {
 		// Check if it's a duplicate URL error
-		if strings.Contains(err.Error(), "duplicate key value violates unique constraint") {
+		if store.IsUniqueViolation(err) {
 			// Ignore duplicate posts
-			return nil
+			return false, nil
 		}
-		return err
+		return false, err
 	}
 
 	fmt.Printf("  Saved: %s\n", item.Title)
+
+	// Rules only run once, when the post is first saved
+	matchPost := rules.Post{
+		Title:       post.Title,
+		Description: post.Description.String,
+		Author:      post.Author.String,
+		Categories:  rules.SplitCategories(post.Categories.String),
+	}
+	for _, rule := range feedRules {
+		// Each owner matches the feed by the name they follow it under, like rules apply does
+		matchPost.Feed = rule.feedName
+		if !rule.matcher.Matches(matchPost) {
+			continue
+		}
+		if err := applyRule(s, rule.Rule, post.ID); err != nil {
+			fmt.Printf("  Error: %v\n", err)
+		}
+	}
+
+	return true, nil
+}
+
+// feedMetadataParams builds the metadata update for a feed from its parsed channel
+func feedMetadataParams(feedID uuid.UUID, rssFeed *rss.RSSFeed) database.UpdateFeedMetadataParams {
+	return database.UpdateFeedMetadataParams{
+		ID:          feedID,
+		Title:       nullString(rssFeed.Channel.Title),
+		Description: nullString(rssFeed.Channel.Description),
+		Link:        nullString(rssFeed.Channel.Link),
+		Language:    nullString(rssFeed.Channel.Language),
+		ImageUrl:    nullString(rssFeed.Channel.Image.URL),
+	}
+}
+
+// findFeedByURL looks up a feed by the identity of its URL, so equivalent spellings of the
+// same URL find the same feed. It returns sql.ErrNoRows when there is no such feed.
+func findFeedByURL(s *State, rawURL string) (database.Feed, error) {
+	urlKey, err := feedurl.Key(rawURL)
+	if err != nil {
+		return database.Feed{}, err
+	}
+
+	// A feed with exactly this URL comes first. Feeds added before URLs were normalized
+	// have no key until 'gator migrate up' sets it, or none at all when they duplicate an
+	// older feed, and can then only be found this way.
+	feed, err := s.DB.GetFeedByURL(context.Background(), strings.TrimSpace(rawURL))
+	if err != sql.ErrNoRows {
+		return feed, err
+	}
+	return s.DB.GetFeedByURLKey(context.Background(), sql.NullString{String: urlKey, Valid: true})
+}
+
+// backfillFeedURLKey sets the URL key of a feed that doesn't have one yet
+func backfillFeedURLKey(s *State, feed database.Feed) error {
+	urlKey, err := feedurl.Key(feed.Url)
+	if err != nil {
+		return err
+	}
+
+	err = s.DB.SetFeedURLKey(context.Background(), database.SetFeedURLKeyParams{
+		ID:     feed.ID,
+		UrlKey: sql.NullString{String: urlKey, Valid: true},
+	})
+	if err != nil && store.IsUniqueViolation(err) {
+		return fmt.Errorf("another feed has an equivalent URL, run 'gator mergefeeds' to combine them")
+	}
+	return err
+}
+
+// nullString converts a string to a sql.NullString, treating blank strings as NULL
+func nullString(value string) sql.NullString {
+	value = strings.TrimSpace(value)
+	return sql.NullString{String: value, Valid: value != ""}
+}
+
+// refreshFeedIcon downloads the icon of a feed when it is missing or stale and stores it if it changed
+func refreshFeedIcon(s *State, feed database.Feed, rssFeed *rss.RSSFeed) error {
+	// Skip feeds whose icon was checked recently
+	existing, err := s.DB.GetFeedIcon(context.Background(), feed.ID)
+	hasIcon := err == nil
+	if err != nil && err != sql.ErrNoRows {
+		return fmt.Errorf("failed to get feed icon: %w", err)
+	}
+	if hasIcon && time.Since(existing.FetchedAt) < iconRefreshInterval {
+		return nil
+	}
+
+	now := time.Now().UTC()
+	icon, err := s.Fetcher.FetchIcon(context.Background(), rss.IconCandidates(rssFeed, feed.Url))
+	if err != nil {
+		// Keep the icon we have and try again after the next interval
+		if hasIcon {
+			markErr := s.DB.MarkFeedIconFetched(context.Background(), database.MarkFeedIconFetchedParams{
+				FeedID:    feed.ID,
+				FetchedAt: now,
+			})
+			if markErr != nil {
+				return errors.Join(err, fmt.Errorf("failed to mark feed icon fetched: %w", markErr))
+			}
+		}
+		return err
+	}
+
+	// Only rewrite the stored icon when its content changed
+	sum := sha256.Sum256(icon.Data)
+	hash := hex.EncodeToString(sum[:])
+	if hasIcon && existing.Sha256 == hash && existing.Url == icon.URL {
+		return s.DB.MarkFeedIconFetched(context.Background(), database.MarkFeedIconFetchedParams{
+			FeedID:    feed.ID,
+			FetchedAt: now,
+		})
+	}
+
+	err = s.DB.UpsertFeedIcon(context.Background(), database.UpsertFeedIconParams{
+		FeedID:      feed.ID,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		FetchedAt:   now,
+		Url:         icon.URL,
+		ContentType: icon.ContentType,
+		Sha256:      hash,
+		Data:        icon.Data,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to store feed icon: %w", err)
+	}
+
+	fmt.Printf("  Updated icon: %s (%s, %d bytes)\n", icon.URL, icon.ContentType, len(icon.Data))
 	return nil
 }
 
 // HandlerAddFeed handles the addfeed command
 func HandlerAddFeed(s *State, cmd Command, user database.User) error {
 	// Check if the command has the required arguments
-	if len(cmd.Args) < 2 {
-		return fmt.Errorf("feed name and URL are required")
+	if len(cmd.Args) == 0 {
+		return fmt.Errorf("feed URL is required")
+	}
+
+	// Get the feed name and URL from arguments, the name is optional
+	var feedName, feedURL string
+	if len(cmd.Args) == 1 {
+		feedURL = cmd.Args[0]
+	} else {
+		feedName = cmd.Args[0]
+		feedURL = cmd.Args[1]
 	}
 
-	// Get the feed name and URL from arguments
-	feedName := cmd.Args[0]
-	feedURL := cmd.Args[1]
+	feed, follow, err := addFeed(s, user, feedName, feedURL)
+	if err != nil {
+		return err
+	}
+
+	// Print the new feed record
+	fmt.Printf("Feed created successfully:\n")
+	fmt.Printf("  ID: %s\n", feed.ID)
+	fmt.Printf("  Name: %s\n", feed.Name)
+	fmt.Printf("  URL: %s\n", feed.Url)
+	if feed.Description.Valid {
+		fmt.Printf("  Description: %s\n", feed.Description.String)
+	}
+	fmt.Printf("  User ID: %s\n", feed.UserID)
+	fmt.Printf("  Created At: %s\n", feed.CreatedAt)
+	fmt.Printf("  Updated At: %s\n", feed.UpdatedAt)
+	fmt.Printf("\nYou are now following %s\n", follow.FeedName)
+
+	return nil
+}
+
+// invalidFeedError is returned by addFeed when the feed itself is the problem, such as a
+// malformed URL or one that doesn't serve a feed, rather than the database
+type invalidFeedError struct {
+	err error
+}
+
+func (e invalidFeedError) Error() string {
+	return e.err.Error()
+}
+
+func (e invalidFeedError) Unwrap() error {
+	return e.err
+}
+
+// addFeed creates a feed and follows it for the user. When the name is empty the feed
+// is fetched and named after its channel title.
+func addFeed(s *State, user database.User, feedName, feedURL string) (database.Feed, database.CreateFeedFollowRow, error) {
+	// Normalize the URL and refuse to add a feed that already exists under an equivalent URL
+	feedURL, err := feedurl.Normalize(feedURL)
+	if err != nil {
+		return database.Feed{}, database.CreateFeedFollowRow{}, invalidFeedError{err}
+	}
+	urlKey, err := feedurl.Key(feedURL)
+	if err != nil {
+		return database.Feed{}, database.CreateFeedFollowRow{}, invalidFeedError{err}
+	}
+	existing, err := findFeedByURL(s, feedURL)
+	if err == nil {
+		return database.Feed{}, database.CreateFeedFollowRow{}, fmt.Errorf("feed '%s' already exists as %s, use 'gator follow %s' instead",
+			existing.Name, existing.Url, existing.Url)
+	}
+	if err != sql.ErrNoRows {
+		return database.Feed{}, database.CreateFeedFollowRow{}, fmt.Errorf("failed to check for existing feed: %w", err)
+	}
+
+	// Fetch the feed to default the name to the channel title
+	var rssFeed *rss.RSSFeed
+	if feedName == "" {
+		rssFeed, err = s.Fetcher.FetchFeed(context.Background(), feedURL)
+		if err != nil {
+			return database.Feed{}, database.CreateFeedFollowRow{}, invalidFeedError{fmt.Errorf("failed to fetch feed to determine its name: %w", err)}
+		}
+		feedName = strings.TrimSpace(rssFeed.Channel.Title)
+		if feedName == "" {
+			return database.Feed{}, database.CreateFeedFollowRow{}, invalidFeedError{fmt.Errorf("feed has no title, please provide a name")}
+		}
+	}
+
+	// Create the feed, its metadata and the follow together so a failure leaves no
+	// feed that nobody follows
+	tx, err := s.DB.BeginTx(context.Background(), nil)
+	if err != nil {
+		return database.Feed{}, database.CreateFeedFollowRow{}, fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback()
 
 	// Create new feed
-	now := time.Now()
-	feed, err := s.DB.CreateFeed(context.Background(), database.CreateFeedParams{
+	now := time.Now().UTC()
+	feed, err := tx.CreateFeed(context.Background(), database.CreateFeedParams{
 		ID:        uuid.New(),
 		CreatedAt: now,
 		UpdatedAt: now,
 		Name:      feedName,
 		Url:       feedURL,
 		UserID:    user.ID,
+		UrlKey:    sql.NullString{String: urlKey, Valid: true},
 	})
 	if err != nil {
-		return fmt.Errorf("failed to create feed: %w", err)
+		return database.Feed{}, database.CreateFeedFollowRow{}, fmt.Errorf("failed to create feed: %w", err)
+	}
+
+	// Store the channel metadata we already fetched
+	if rssFeed != nil {
+		metadata := feedMetadataParams(feed.ID, rssFeed)
+		err = tx.UpdateFeedMetadata(context.Background(), metadata)
+		if err != nil {
+			return database.Feed{}, database.CreateFeedFollowRow{}, fmt.Errorf("failed to store feed metadata: %w", err)
+		}
+		feed.Title = metadata.Title
+		feed.Description = metadata.Description
+		feed.Link = metadata.Link
+		feed.Language = metadata.Language
+		feed.ImageUrl = metadata.ImageUrl
 	}
 
 	// Automatically create a feed follow record for the current user
-	follow, err := s.DB.CreateFeedFollow(context.Background(), database.CreateFeedFollowParams{
+	follow, err := tx.CreateFeedFollow(context.Background(), database.CreateFeedFollowParams{
 		ID:        uuid.New(),
 		CreatedAt: now,
 		UpdatedAt: now,
@@ -287,20 +757,13 @@ func HandlerAddFeed(s *State, cmd Command, user database.User) error {
 		FeedID:    feed.ID,
 	})
 	if err != nil {
-		return fmt.Errorf("failed to create feed follow: %w", err)
+		return database.Feed{}, database.CreateFeedFollowRow{}, fmt.Errorf("failed to create feed follow: %w", err)
 	}
 
-	// Print the new feed record
-	fmt.Printf("Feed created successfully:\n")
-	fmt.Printf("  ID: %s\n", feed.ID)
-	fmt.Printf("  Name: %s\n", feed.Name)
-	fmt.Printf("  URL: %s\n", feed.Url)
-	fmt.Printf("  User ID: %s\n", feed.UserID)
-	fmt.Printf("  Created At: %s\n", feed.CreatedAt)
-	fmt.Printf("  Updated At: %s\n", feed.UpdatedAt)
-	fmt.Printf("\nYou are now following %s\n", follow.FeedName)
-
-	return nil
+	if err := tx.Commit(); err != nil {
+		return database.Feed{}, database.CreateFeedFollowRow{}, fmt.Errorf("failed to commit feed: %w", err)
+	}
+	return feed, follow, nil
 }
 
 // HandlerFeeds handles the feeds command
@@ -318,18 +781,95 @@ func HandlerFeeds(s *State, cmd Command) error {
 	}
 
 	// Print all feeds
+	loc := currentLocation(s)
 	fmt.Printf("Found %d feed(s):\n\n", len(feeds))
 	for i, feed := range feeds {
-		fmt.Printf("%d. %s\n", i+1, feed.Name)
+		if feed.Paused {
+			fmt.Printf("%d. %s (paused)\n", i+1, feed.Name)
+		} else {
+			fmt.Printf("%d. %s\n", i+1, feed.Name)
+		}
 		fmt.Printf("   URL: %s\n", feed.Url)
+		if feed.Title.Valid && feed.Title.String != feed.Name {
+			fmt.Printf("   Title: %s\n", feed.Title.String)
+		}
+		if feed.Description.Valid {
+			fmt.Printf("   Description: %s\n", feed.Description.String)
+		}
+		if feed.Link.Valid {
+			fmt.Printf("   Site: %s\n", feed.Link.String)
+		}
+		if feed.Language.Valid {
+			fmt.Printf("   Language: %s\n", feed.Language.String)
+		}
+		if feed.ImageUrl.Valid {
+			fmt.Printf("   Image: %s\n", feed.ImageUrl.String)
+		}
+		if feed.IconContentType.Valid {
+			fmt.Printf("   Icon: %s\n", feed.IconContentType.String)
+		}
 		fmt.Printf("   Created by: %s\n", feed.UserName)
-		fmt.Printf("   Created at: %s\n", feed.CreatedAt.Format("2006-01-02 15:04:05"))
+		fmt.Printf("   Created at: %s\n", formatTime(feed.CreatedAt, loc))
+		if feed.LastFetchedAt.Valid {
+			fmt.Printf("   Last fetched: %s\n", formatTime(feed.LastFetchedAt.Time, loc))
+		}
 		fmt.Println()
 	}
 
 	return nil
 }
 
+// HandlerIcon handles the icon command
+func HandlerIcon(s *State, cmd Command) error {
+	// Check if the command has the required argument
+	if len(cmd.Args) == 0 {
+		return fmt.Errorf("feed URL is required")
+	}
+
+	// Look up the feed and its stored icon
+	feedURL := cmd.Args[0]
+	feed, err := findFeedByURL(s, feedURL)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("feed with URL '%s' not found", feedURL)
+		}
+		return fmt.Errorf("failed to get feed: %w", err)
+	}
+
+	icon, err := s.DB.GetFeedIcon(context.Background(), feed.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("feed '%s' has no icon yet, run the aggregator to fetch it", feed.Name)
+		}
+		return fmt.Errorf("failed to get feed icon: %w", err)
+	}
+
+	// Write the icon bytes to a file, or to stdout when the file is "-"
+	if len(cmd.Args) > 1 {
+		outputPath := cmd.Args[1]
+		if outputPath == "-" {
+			_, err = os.Stdout.Write(icon.Data)
+		} else {
+			err = os.WriteFile(outputPath, icon.Data, 0644)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to write icon: %w", err)
+		}
+		return nil
+	}
+
+	// Print the icon details
+	loc := currentLocation(s)
+	fmt.Printf("Icon for %s:\n", feed.Name)
+	fmt.Printf("  URL: %s\n", icon.Url)
+	fmt.Printf("  Content type: %s\n", icon.ContentType)
+	fmt.Printf("  Size: %d bytes\n", len(icon.Data))
+	fmt.Printf("  SHA-256: %s\n", icon.Sha256)
+	fmt.Printf("  Updated at: %s\n", formatTime(icon.UpdatedAt, loc))
+	fmt.Printf("  Checked at: %s\n", formatTime(icon.FetchedAt, loc))
+	return nil
+}
+
 // HandlerFollow handles the follow command
 func HandlerFollow(s *State, cmd Command, user database.User) error {
 	// Check if the command has the required argument
@@ -341,7 +881,7 @@ func HandlerFollow(s *State, cmd Command, user database.User) error {
 	feedURL := cmd.Args[0]
 
 	// Look up the feed by URL
-	feed, err := s.DB.GetFeedByURL(context.Background(), feedURL)
+	feed, err := findFeedByURL(s, feedURL)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return fmt.Errorf("feed with URL '%s' not found", feedURL)
@@ -350,7 +890,7 @@ func HandlerFollow(s *State, cmd Command, user database.User) error {
 	}
 
 	// Create feed follow record
-	now := time.Now()
+	now := time.Now().UTC()
 	follow, err := s.DB.CreateFeedFollow(context.Background(), database.CreateFeedFollowParams{
 		ID:        uuid.New(),
 		CreatedAt: now,
@@ -369,14 +909,43 @@ func HandlerFollow(s *State, cmd Command, user database.User) error {
 
 // HandlerFollowing handles the following command
 func HandlerFollowing(s *State, cmd Command, user database.User) error {
+	fs := newFlagSet(cmd)
+	folder := fs.String("folder", "", "only show feeds in this folder or tag")
+	if _, err := parseFlags(fs, cmd.Args); err != nil {
+		return err
+	}
+
 	// Get all feed follows for the current user
 	follows, err := s.DB.GetFeedFollowsForUser(context.Background(), user.ID)
 	if err != nil {
 		return fmt.Errorf("failed to get feed follows: %w", err)
 	}
+	tags, err := getFollowTags(s, user)
+	if err != nil {
+		return err
+	}
+
+	// Keep only the feeds filed under the requested folder
+	if *folder != "" {
+		name, err := normalizeTag(*folder)
+		if err != nil {
+			return err
+		}
+		var filtered []database.GetFeedFollowsForUserRow
+		for _, follow := range follows {
+			if inFolder(tags[follow.FeedID], name) {
+				filtered = append(filtered, follow)
+			}
+		}
+		follows = filtered
+	}
 
 	// Check if user is following any feeds
 	if len(follows) == 0 {
+		if *folder != "" {
+			fmt.Printf("You are not following any feeds in %s.\n", *folder)
+			return nil
+		}
 		fmt.Println("You are not following any feeds.")
 		return nil
 	}
@@ -384,8 +953,15 @@ func HandlerFollowing(s *State, cmd Command, user database.User) error {
 	// Print all followed feeds
 	fmt.Printf("You are following %d feed(s):\n\n", len(follows))
 	for i, follow := range follows {
-		fmt.Printf("%d. %s\n", i+1, follow.FeedName)
-		fmt.Printf("   Followed at: %s\n", follow.CreatedAt.Format("2006-01-02 15:04:05"))
+		fmt.Printf("%d. %s%s\n", i+1, follow.FeedName, followMarkers(follow.Muted, follow.Notify))
+		if follow.Priority != 0 {
+			fmt.Printf("   Priority: %d\n", follow.Priority)
+		}
+		if len(tags[follow.FeedID]) > 0 {
+			fmt.Printf("   Tags: %s\n", strings.Join(tags[follow.FeedID], ", "))
+		}
+		fmt.Printf("   Unread: %d\n", follow.UnreadCount)
+		fmt.Printf("   Followed at: %s\n", formatTime(follow.CreatedAt, userLocation(user)))
 		fmt.Println()
 	}
 
@@ -403,7 +979,7 @@ func HandlerUnfollow(s *State, cmd Command, user database.User) error {
 	feedURL := cmd.Args[0]
 
 	// Look up the feed by URL
-	feed, err := s.DB.GetFeedByURL(context.Background(), feedURL)
+	feed, err := findFeedByURL(s, feedURL)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return fmt.Errorf("feed with URL '%s' not found", feedURL)
@@ -425,30 +1001,123 @@ func HandlerUnfollow(s *State, cmd Command, user database.User) error {
 	return nil
 }
 
+// postMarkers returns the unread and starred markers shown after a post title
+func postMarkers(read, starred bool) string {
+	var markers string
+	if !read {
+		markers += " [unread]"
+	}
+	if starred {
+		markers += " [starred]"
+	}
+	return markers
+}
+
+// maxPageSize is the most posts browse and search show at once
+const maxPageSize = 1000
+
 // HandlerBrowse handles the browse command
 func HandlerBrowse(s *State, cmd Command, user database.User) error {
-	// Default limit is 2
-	limit := int32(2)
-	
-	// Check if limit argument is provided
-	if len(cmd.Args) > 0 {
+	fs := newFlagSet(cmd)
+	limit := fs.Int("limit", 10, "maximum number of posts to show")
+	offset := fs.Int("offset", 0, "number of posts to skip")
+	after := fs.String("after", "", "continue after the cursor printed by a previous page")
+	feedURL := fs.String("feed", "", "only show posts of this feed")
+	since := fs.String("since", "", "only show posts on or after this date")
+	until := fs.String("until", "", "only show posts before this date")
+	sortBy := fs.String("sort", "published", "order posts by published or fetched time")
+	unreadOnly := fs.Bool("unread", false, "only show posts you haven't read")
+	folder := fs.String("folder", "", "only show posts of feeds in this folder or tag")
+	tag := fs.String("tag", "", "only show posts tagged by your rules with this tag")
+	args, err := parseFlags(fs, cmd.Args)
+	if err != nil {
+		return err
+	}
+
+	// A positional limit is still accepted for compatibility
+	if len(args) > 0 {
 		// Parse limit argument
-		if parsedLimit, err := fmt.Sscanf(cmd.Args[0], "%d", &limit); err != nil || parsedLimit != 1 {
-			return fmt.Errorf("invalid limit format: %s", cmd.Args[0])
+		if parsedLimit, err := fmt.Sscanf(args[0], "%d", limit); err != nil || parsedLimit != 1 {
+			return fmt.Errorf("invalid limit format: %s", args[0])
+		}
+	}
+	// Check the page before it is narrowed to the database's 32-bit integers
+	if *limit <= 0 || *limit > maxPageSize {
+		return fmt.Errorf("invalid limit %d, use a number from 1 to %d", *limit, maxPageSize)
+	}
+	if *offset < 0 || *offset > math.MaxInt32 {
+		return fmt.Errorf("invalid offset %d, use a number from 0 to %d", *offset, math.MaxInt32)
+	}
+	if *sortBy != "published" && *sortBy != "fetched" {
+		return fmt.Errorf("invalid sort '%s', use published or fetched", *sortBy)
+	}
+
+	params := database.GetPostsForUserParams{
+		UserID:     user.ID,
+		SortBy:     *sortBy,
+		UnreadOnly: *unreadOnly,
+		Limit:      int32(*limit),
+		Offset:     int32(*offset),
+	}
+	if *feedURL != "" {
+		feed, err := findFeedByURL(s, *feedURL)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return fmt.Errorf("feed with URL '%s' not found", *feedURL)
+			}
+			return fmt.Errorf("failed to get feed: %w", err)
+		}
+		params.FeedID = uuid.NullUUID{UUID: feed.ID, Valid: true}
+	}
+	if *folder != "" {
+		name, err := normalizeTag(*folder)
+		if err != nil {
+			return err
+		}
+		params.Folder = sql.NullString{String: name, Valid: true}
+	}
+	if *tag != "" {
+		name, err := normalizeTag(*tag)
+		if err != nil {
+			return err
 		}
+		params.Tag = sql.NullString{String: name, Valid: true}
+	}
+	if *since != "" {
+		date, err := parseDate(*since, userLocation(user))
+		if err != nil {
+			return err
+		}
+		params.Since = sql.NullTime{Time: date, Valid: true}
+	}
+	if *until != "" {
+		date, err := parseDate(*until, userLocation(user))
+		if err != nil {
+			return err
+		}
+		params.Until = sql.NullTime{Time: date, Valid: true}
+	}
+	if *after != "" {
+		sortAt, id, err := decodeCursor(*after, *sortBy)
+		if err != nil {
+			return err
+		}
+		params.AfterSortAt = sql.NullTime{Time: sortAt, Valid: true}
+		params.AfterID = uuid.NullUUID{UUID: id, Valid: true}
 	}
 
 	// Get posts for the user
-	posts, err := s.DB.GetPostsForUser(context.Background(), database.GetPostsForUserParams{
-		UserID: user.ID,
-		Limit:  limit,
-	})
+	posts, err := s.DB.GetPostsForUser(context.Background(), params)
 	if err != nil {
 		return fmt.Errorf("failed to get posts: %w", err)
 	}
 
 	// Check if there are any posts
 	if len(posts) == 0 {
+		if *unreadOnly {
+			fmt.Println("No unread posts.")
+			return nil
+		}
 		fmt.Println("No posts found. Make sure you're following some feeds and the aggregator is running.")
 		return nil
 	}
@@ -456,8 +1125,12 @@ func HandlerBrowse(s *State, cmd Command, user database.User) error {
 	// Print posts
 	fmt.Printf("Found %d post(s):\n\n", len(posts))
 	for i, post := range posts {
-		fmt.Printf("%d. %s\n", i+1, post.Title)
+		fmt.Printf("%d. %s%s\n", i+1, post.Title, postMarkers(post.ReadAt.Valid, post.StarredAt.Valid))
+		fmt.Printf("   ID: %s\n", post.ID)
 		fmt.Printf("   Feed: %s\n", post.FeedName)
+		if post.Tags != "" {
+			fmt.Printf("   Tags: %s\n", post.Tags)
+		}
 		fmt.Printf("   URL: %s\n", post.Url)
 		if post.Description.Valid && post.Description.String != "" {
 			// Truncate description if too long
@@ -468,10 +1141,49 @@ func HandlerBrowse(s *State, cmd Command, user database.User) error {
 			fmt.Printf("   Description: %s\n", desc)
 		}
 		if post.PublishedAt.Valid {
-			fmt.Printf("   Published: %s\n", post.PublishedAt.Time.Format("2006-01-02 15:04:05"))
+			fmt.Printf("   Published: %s\n", formatTime(post.PublishedAt.Time, userLocation(user)))
 		}
 		fmt.Println()
 	}
 
+	// A full page may be followed by more posts
+	if len(posts) == *limit {
+		last := posts[len(posts)-1]
+		fmt.Printf("Next page: --after %s\n", encodeCursor(*sortBy, last.SortAt, last.ID))
+	}
+
 	return nil
+}
+
+// encodeCursor builds an opaque browse cursor pointing at a post in the timeline
+func encodeCursor(sortBy string, sortAt time.Time, id uuid.UUID) string {
+	raw := fmt.Sprintf("%s|%s|%s", sortBy, sortAt.Format(time.RFC3339Nano), id)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// decodeCursor parses a browse cursor, checking it was made for the same sort order
+func decodeCursor(cursor, sortBy string) (time.Time, uuid.UUID, error) {
+	invalid := fmt.Errorf("invalid cursor: %s", cursor)
+
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return time.Time{}, uuid.Nil, invalid
+	}
+	parts := strings.Split(string(raw), "|")
+	if len(parts) != 3 {
+		return time.Time{}, uuid.Nil, invalid
+	}
+	if parts[0] != sortBy {
+		return time.Time{}, uuid.Nil, fmt.Errorf("cursor was created with --sort %s", parts[0])
+	}
+	sortAt, err := time.Parse(time.RFC3339Nano, parts[1])
+	if err != nil {
+		return time.Time{}, uuid.Nil, invalid
+	}
+	id, err := uuid.Parse(parts[2])
+	if err != nil {
+		return time.Time{}, uuid.Nil, invalid
+	}
+
+	return sortAt, id, nil
 }
\ No newline at end of file
@@ -0,0 +1,104 @@
+package cli
+
+import (
+	"context"
+	"testing"
+)
+
+func TestAddFeedAndFollow(t *testing.T) {
+	s, server := newTestState(t)
+	registerUser(t, s, "alice")
+
+	// Without a name the feed is named after its channel title
+	mustRun(t, s, "addfeed", server.URL+"/alpha.xml")
+	feeds, err := s.DB.GetFeeds(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(feeds) != 1 || feeds[0].Name != "Alpha Blog" {
+		t.Fatalf("got feeds %+v, want one named Alpha Blog", feeds)
+	}
+	if !feeds[0].UrlKey.Valid {
+		t.Error("feed was added without a URL key")
+	}
+
+	// Another spelling of the same URL is the same feed
+	if _, err := run(t, s, "addfeed", "Again", server.URL+"/alpha.xml#latest"); err == nil {
+		t.Error("added the same feed twice")
+	}
+	if _, err := run(t, s, "addfeed", server.URL+"/missing.xml"); err == nil {
+		t.Error("added a feed that can't be fetched")
+	}
+
+	registerUser(t, s, "bob")
+	mustRun(t, s, "follow", server.URL+"/alpha.xml")
+	out := mustRun(t, s, "following")
+	assertContains(t, out, "Alpha Blog")
+
+	stats := userStats(t, s)
+	if stats.FollowCount != 1 || stats.FeedCount != 0 {
+		t.Errorf("bob follows %d feed(s) and added %d, want 1 and 0", stats.FollowCount, stats.FeedCount)
+	}
+}
+
+func TestAggBrowseMarkReadSearch(t *testing.T) {
+	s, server := newTestState(t)
+	registerUser(t, s, "alice")
+	mustRun(t, s, "addfeed", server.URL+"/alpha.xml")
+	mustRun(t, s, "addfeed", server.URL+"/beta.xml")
+
+	aggregate(t, s)
+	if stats := userStats(t, s); stats.PostCount != 5 || stats.UnreadCount != 5 {
+		t.Fatalf("got %d post(s), %d unread, want 5 unread", stats.PostCount, stats.UnreadCount)
+	}
+
+	// Fetching again only adds new items
+	aggregate(t, s)
+	if stats := userStats(t, s); stats.PostCount != 5 {
+		t.Fatalf("got %d post(s) after fetching again, want 5", stats.PostCount)
+	}
+
+	out := mustRun(t, s, "browse")
+	assertContains(t, out, "Postgres release notes", "Weekly roundup", "Running databases on laptops")
+	out = mustRun(t, s, "browse", "--feed", server.URL+"/alpha.xml")
+	assertContains(t, out, "Postgres performance")
+	assertNotContains(t, out, "Weekly roundup")
+
+	mustRun(t, s, "markread", "--feed", server.URL+"/beta.xml")
+	if stats := userStats(t, s); stats.UnreadCount != 3 {
+		t.Errorf("got %d unread post(s) after marking a feed read, want 3", stats.UnreadCount)
+	}
+	out = mustRun(t, s, "browse", "--unread")
+	assertNotContains(t, out, "Weekly roundup")
+	mustRun(t, s, "markread", "--all")
+	if stats := userStats(t, s); stats.UnreadCount != 0 {
+		t.Errorf("got %d unread post(s) after marking all read, want 0", stats.UnreadCount)
+	}
+
+	out = mustRun(t, s, "search", "postgres")
+	assertContains(t, out, "Postgres performance", "Postgres release notes")
+	assertNotContains(t, out, "Gardening tips")
+	out = mustRun(t, s, "search", "postgres -release")
+	assertContains(t, out, "Postgres performance")
+	assertNotContains(t, out, "Postgres release notes")
+	out = mustRun(t, s, "search", `"lightweight database"`)
+	assertContains(t, out, "Running databases on laptops")
+}
+
+func TestBrowsePageBounds(t *testing.T) {
+	s, _ := newTestState(t)
+	registerUser(t, s, "alice")
+
+	for _, args := range [][]string{
+		{"--limit", "0"},
+		{"--limit", "4294967297"},
+		{"--offset", "-1"},
+		{"--offset", "4294967296"},
+		{"0"},
+	} {
+		if _, err := run(t, s, "browse", args...); err == nil {
+			t.Errorf("browse %v succeeded", args)
+		}
+	}
+	mustRun(t, s, "browse", "--limit", "1000", "--offset", "2147483647")
+}
@@ -0,0 +1,57 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/PassZ/rss-aggregator/internal/config"
+	"github.com/PassZ/rss-aggregator/internal/store"
+)
+
+func TestInit(t *testing.T) {
+	s, server := newTestState(t)
+	s.DB = nil
+
+	// Set up a new SQLite database with a first user and their subscriptions
+	dbURL := "sqlite://" + filepath.Join(t.TempDir(), "gator.db")
+	opmlPath := filepath.Join(t.TempDir(), "subscriptions.opml")
+	subscriptions := `<opml version="2.0"><body><outline text="Alpha" xmlUrl="` + server.URL + `/alpha.xml"/></body></opml>`
+	if err := os.WriteFile(opmlPath, []byte(subscriptions), 0600); err != nil {
+		t.Fatal(err)
+	}
+	setInput(t, dbURL, "alice", "", opmlPath)
+	out := mustRun(t, s, "init")
+	assertContains(t, out, "Connected.", "Applied", "Created: 1", "Gator is ready")
+
+	saved, err := config.Read(s.Config.Path, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.DbURL != dbURL || saved.SessionToken == "" {
+		t.Fatalf("got db_url %q and session %q saved, want %q and a session", saved.DbURL, saved.SessionToken, dbURL)
+	}
+
+	st, err := store.Open(dbURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer st.Close()
+	s.DB = st
+	if user := loggedInUser(t, s); user.Name != "alice" || user.Role != roleAdmin {
+		t.Errorf("got %s (%s) logged in, want alice as admin", user.Name, user.Role)
+	}
+	if stats := userStats(t, s); stats.FollowCount != 1 {
+		t.Errorf("alice follows %d feed(s), want 1", stats.FollowCount)
+	}
+
+	// A profile that is set up is only set up again when asked to
+	setInput(t, "n")
+	out = mustRun(t, s, "init")
+	assertContains(t, out, "Nothing changed.")
+
+	// Setting up the same database again keeps its users
+	setInput(t, "y", dbURL)
+	out = mustRun(t, s, "init")
+	assertContains(t, out, "Database schema is up to date.", "already has 1 user(s)")
+}
@@ -0,0 +1,157 @@
+package cli
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/PassZ/rss-aggregator/internal/database"
+	"github.com/PassZ/rss-aggregator/internal/feedurl"
+	"github.com/PassZ/rss-aggregator/internal/migrate"
+)
+
+// HandlerMigrate handles the migrate command
+func HandlerMigrate(s *State, cmd Command) error {
+	// Check if the command has the required argument
+	if len(cmd.Args) == 0 {
+		return fmt.Errorf("usage: migrate <up|down|status|version>")
+	}
+
+	provider, err := migrate.NewProvider(s.DB)
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	switch cmd.Args[0] {
+	case "up":
+		results, err := provider.Up(ctx)
+		for _, result := range results {
+			fmt.Printf("Applied %s (%s)\n", result.Source.Path, result.Duration.Round(time.Millisecond))
+		}
+		if err != nil {
+			return fmt.Errorf("failed to migrate: %w", err)
+		}
+		if len(results) == 0 {
+			fmt.Println("Database schema is up to date")
+		}
+		if err := backfillFeedURLKeys(s); err != nil {
+			return err
+		}
+
+	case "down":
+		if err := checkCanRollBack(s); err != nil {
+			return err
+		}
+
+		// Rolling back can drop columns and tables, so only go one step at a time
+		result, err := provider.Down(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to roll back: %w", err)
+		}
+		fmt.Printf("Rolled back %s (%s)\n", result.Source.Path, result.Duration.Round(time.Millisecond))
+
+	case "status":
+		statuses, err := provider.Status(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get migration status: %w", err)
+		}
+		loc := currentLocation(s)
+		for _, status := range statuses {
+			applied := "pending"
+			if !status.AppliedAt.IsZero() {
+				applied = status.AppliedAt.In(loc).Format(timeFormat)
+			}
+			fmt.Printf("%-25s %s\n", applied, status.Source.Path)
+		}
+
+	case "version":
+		current, target, err := provider.GetVersions(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get schema version: %w", err)
+		}
+		fmt.Printf("Database schema version %d, latest is %d\n", current, target)
+
+	default:
+		return fmt.Errorf("unknown migrate subcommand '%s', use up, down, status or version", cmd.Args[0])
+	}
+	return nil
+}
+
+// checkCanRollBack lets only admins roll back migrations, as that drops columns and tables.
+// Like restore, a database without users is open to anyone.
+func checkCanRollBack(s *State) error {
+	users, err := s.DB.GetUsers(context.Background())
+	if err != nil {
+		return fmt.Errorf("failed to get users, roll back with the goose CLI instead: %w", err)
+	}
+	if len(users) == 0 {
+		return nil
+	}
+
+	user, err := currentUser(s)
+	if err != nil {
+		return err
+	}
+	if user.Role != roleAdmin {
+		return fmt.Errorf("only admins can roll back migrations")
+	}
+	return nil
+}
+
+// backfillFeedURLKeys sets the URL key of feeds added before URLs were normalized, so
+// adding one of them again under another spelling is caught. Feeds whose key is taken
+// by an older feed are duplicates: they keep no key and are reported for merging.
+func backfillFeedURLKeys(s *State) error {
+	feeds, err := s.DB.GetFeeds(context.Background())
+	if err != nil {
+		return fmt.Errorf("failed to get feeds: %w", err)
+	}
+
+	owners := make(map[string]database.GetFeedsRow)
+	for _, feed := range feeds {
+		if feed.UrlKey.Valid {
+			owners[feed.UrlKey.String] = feed
+		}
+	}
+
+	// Feeds are listed newest first, the oldest one keeps the key
+	var filled, duplicates int
+	for i := len(feeds) - 1; i >= 0; i-- {
+		feed := feeds[i]
+		if feed.UrlKey.Valid {
+			continue
+		}
+		urlKey, err := feedurl.Key(feed.Url)
+		if err != nil {
+			fmt.Printf("Skipped URL key of feed %s: %v\n", feed.Name, err)
+			continue
+		}
+		if owner, ok := owners[urlKey]; ok {
+			fmt.Printf("Feed %s (%s) duplicates %s (%s), merge them with 'gator mergefeeds %s %s'\n",
+				feed.Name, feed.Url, owner.Name, owner.Url, owner.Url, feed.Url)
+			duplicates++
+			continue
+		}
+
+		err = s.DB.SetFeedURLKey(context.Background(), database.SetFeedURLKeyParams{
+			ID:     feed.ID,
+			UrlKey: sql.NullString{String: urlKey, Valid: true},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to set URL key of feed %s: %w", feed.Name, err)
+		}
+		feed.UrlKey = sql.NullString{String: urlKey, Valid: true}
+		owners[urlKey] = feed
+		filled++
+	}
+
+	if filled > 0 {
+		fmt.Printf("Set the URL key of %d feed(s)\n", filled)
+	}
+	if duplicates > 0 {
+		fmt.Printf("Found %d duplicate feed(s), they are left as they are until merged\n", duplicates)
+	}
+	return nil
+}
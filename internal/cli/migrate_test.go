@@ -0,0 +1,56 @@
+package cli
+
+import (
+	"context"
+	"testing"
+
+	"github.com/PassZ/rss-aggregator/internal/migrate"
+)
+
+func TestMigrate(t *testing.T) {
+	s, _ := newTestState(t)
+	provider, err := migrate.NewProvider(s.DB)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, latest, err := provider.GetVersions(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out := mustRun(t, s, "migrate", "version")
+	assertContains(t, out, "Database schema version")
+	out = mustRun(t, s, "migrate", "up")
+	assertContains(t, out, "Database schema is up to date")
+	out = mustRun(t, s, "migrate", "status")
+	assertContains(t, out, "001_")
+	assertNotContains(t, out, "pending")
+
+	// Only admins roll back once there are users
+	registerUser(t, s, "alice")
+	registerUser(t, s, "bob")
+	if _, err := run(t, s, "migrate", "down"); err == nil {
+		t.Fatal("a member rolled back a migration")
+	}
+	setInput(t, "")
+	mustRun(t, s, "login", "alice")
+	out = mustRun(t, s, "migrate", "down")
+	assertContains(t, out, "Rolled back")
+	current, _, err := provider.GetVersions(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if current != latest-1 {
+		t.Errorf("got schema version %d after rolling back, want %d", current, latest-1)
+	}
+
+	out = mustRun(t, s, "migrate", "up")
+	assertContains(t, out, "Applied")
+	if current, _, _ := provider.GetVersions(context.Background()); current != latest {
+		t.Errorf("got schema version %d after migrating, want %d", current, latest)
+	}
+
+	if _, err := run(t, s, "migrate", "sideways"); err == nil {
+		t.Error("ran an unknown migrate subcommand")
+	}
+}
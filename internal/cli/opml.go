@@ -0,0 +1,318 @@
+package cli
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/PassZ/rss-aggregator/internal/database"
+	"github.com/PassZ/rss-aggregator/internal/feedurl"
+	"github.com/PassZ/rss-aggregator/internal/opml"
+)
+
+// importStatus is the outcome of importing a single OPML subscription
+type importStatus string
+
+const (
+	importCreated  importStatus = "created"
+	importFollowed importStatus = "followed"
+	importSkipped  importStatus = "skipped"
+	importInvalid  importStatus = "invalid"
+)
+
+// HandlerImport handles the import command
+func HandlerImport(s *State, cmd Command, user database.User) error {
+	fs := newFlagSet(cmd)
+	dryRun := fs.Bool("dry-run", false, "report what would be imported without changing anything")
+	args, err := parseFlags(fs, cmd.Args)
+	if err != nil {
+		return err
+	}
+
+	// Check if the command has the required arguments
+	if len(args) < 2 || args[0] != "opml" {
+		return fmt.Errorf("usage: import opml <file> [--dry-run]")
+	}
+
+	// Read and parse the OPML file
+	doc, err := readOPML(args[1])
+	if err != nil {
+		return err
+	}
+	subs := doc.Subscriptions()
+	if len(subs) == 0 {
+		fmt.Println("No feeds found in the OPML file.")
+		return nil
+	}
+
+	if *dryRun {
+		fmt.Println("Dry run: no changes will be made.")
+	}
+	fmt.Printf("Importing %d feed(s):\n\n", len(subs))
+
+	// Import each subscription, continuing past invalid entries
+	counts := make(map[importStatus]int)
+	seen := make(map[string]uuid.UUID)
+	for _, sub := range subs {
+		status, detail, err := importSubscription(s, user, sub, seen, *dryRun)
+		if err != nil {
+			return err
+		}
+		counts[status]++
+
+		name := sub.Title
+		if name == "" {
+			name = sub.XMLURL
+		}
+		if sub.Folder != "" {
+			name = sub.Folder + "/" + name
+		}
+		fmt.Printf("  %-8s %s (%s)\n", status, name, detail)
+	}
+
+	// Print summary
+	fmt.Printf("\nCreated: %d, Followed: %d, Skipped: %d, Invalid: %d\n",
+		counts[importCreated], counts[importFollowed], counts[importSkipped], counts[importInvalid])
+	return nil
+}
+
+// importSubscription creates and/or follows the feed of a single OPML subscription
+func importSubscription(s *State, user database.User, sub opml.Subscription, seen map[string]uuid.UUID, dryRun bool) (importStatus, string, error) {
+	// Validate the feed URL
+	if sub.XMLURL == "" {
+		return importInvalid, "missing feed URL", nil
+	}
+	feedURL, err := feedurl.Normalize(sub.XMLURL)
+	if err != nil {
+		return importInvalid, err.Error(), nil
+	}
+	urlKey, err := feedurl.Key(feedURL)
+	if err != nil {
+		return importInvalid, err.Error(), nil
+	}
+
+	// Skip entries listed more than once in the file, in any spelling,
+	// but keep every folder the feed was filed under
+	if feedID, ok := seen[urlKey]; ok {
+		if dryRun || feedID == uuid.Nil || sub.Folder == "" {
+			return importSkipped, "duplicate entry", nil
+		}
+		if err := setImportedFolder(s, user, feedID, sub.Folder); err != nil {
+			return "", "", err
+		}
+		return importSkipped, "duplicate entry, added to folder", nil
+	}
+	seen[urlKey] = uuid.Nil
+
+	// Create the feed if nobody has added it yet
+	feed, err := findFeedByURL(s, feedURL)
+	if err == sql.ErrNoRows {
+		if dryRun {
+			return importCreated, feedURL, nil
+		}
+		// Feeds that can't be added are reported, but database failures stop the import
+		feed, _, err := addFeed(s, user, sub.Title, feedURL)
+		var invalid invalidFeedError
+		if errors.As(err, &invalid) {
+			return importInvalid, err.Error(), nil
+		}
+		if err != nil {
+			return "", "", err
+		}
+		seen[urlKey] = feed.ID
+		if err := setImportedFolder(s, user, feed.ID, sub.Folder); err != nil {
+			return "", "", err
+		}
+		return importCreated, feedURL, nil
+	}
+	if err != nil {
+		return "", "", fmt.Errorf("failed to get feed: %w", err)
+	}
+
+	// Follow the existing feed unless we already do
+	_, err = s.DB.GetFeedFollow(context.Background(), database.GetFeedFollowParams{
+		UserID: user.ID,
+		FeedID: feed.ID,
+	})
+	if err == nil {
+		seen[urlKey] = feed.ID
+		if !dryRun {
+			if err := setImportedFolder(s, user, feed.ID, sub.Folder); err != nil {
+				return "", "", err
+			}
+		}
+		return importSkipped, "already following", nil
+	}
+	if err != sql.ErrNoRows {
+		return "", "", fmt.Errorf("failed to check feed follow: %w", err)
+	}
+	if dryRun {
+		return importFollowed, feedURL, nil
+	}
+
+	now := time.Now().UTC()
+	_, err = s.DB.CreateFeedFollow(context.Background(), database.CreateFeedFollowParams{
+		ID:        uuid.New(),
+		CreatedAt: now,
+		UpdatedAt: now,
+		UserID:    user.ID,
+		FeedID:    feed.ID,
+	})
+	if err != nil {
+		return "", "", fmt.Errorf("failed to create feed follow: %w", err)
+	}
+	seen[urlKey] = feed.ID
+	if err := setImportedFolder(s, user, feed.ID, sub.Folder); err != nil {
+		return "", "", err
+	}
+	return importFollowed, feedURL, nil
+}
+
+// setImportedFolder tags a followed feed with the folder it had in the OPML file
+func setImportedFolder(s *State, user database.User, feedID uuid.UUID, folder string) error {
+	if folder == "" {
+		return nil
+	}
+	// Folders made only of blank names are left out
+	tag, err := normalizeTag(folder)
+	if err != nil {
+		return nil
+	}
+
+	follow, err := s.DB.GetFeedFollow(context.Background(), database.GetFeedFollowParams{
+		UserID: user.ID,
+		FeedID: feedID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get feed follow: %w", err)
+	}
+	err = s.DB.AddFeedFollowTag(context.Background(), database.AddFeedFollowTagParams{
+		FeedFollowID: follow.ID,
+		Tag:          tag,
+		CreatedAt:    time.Now().UTC(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set folder: %w", err)
+	}
+	return nil
+}
+
+// HandlerExport handles the export command
+func HandlerExport(s *State, cmd Command) error {
+	fs := newFlagSet(cmd)
+	userName := fs.String("user", "", "export the feeds followed by this user instead of the current user")
+	all := fs.Bool("all", false, "export every feed in the database")
+	args, err := parseFlags(fs, cmd.Args)
+	if err != nil {
+		return err
+	}
+
+	// Check if the command has the required arguments
+	if len(args) == 0 || args[0] != "opml" {
+		return fmt.Errorf("usage: export opml [file] [--user name] [--all]")
+	}
+	if *all && *userName != "" {
+		return fmt.Errorf("--user and --all cannot be combined")
+	}
+
+	// Collect the subscriptions to export
+	var title string
+	var subs []opml.Subscription
+	if *all {
+		feeds, err := s.DB.GetFeeds(context.Background())
+		if err != nil {
+			return fmt.Errorf("failed to get feeds: %w", err)
+		}
+		title = "All gator feeds"
+		for _, feed := range feeds {
+			subs = append(subs, opml.Subscription{
+				Title:   feed.Name,
+				XMLURL:  feed.Url,
+				HTMLURL: feed.Link.String,
+			})
+		}
+	} else {
+		// Default to the logged in user, only admins may export someone else's feeds
+		user, err := currentUser(s)
+		if err != nil {
+			return err
+		}
+		if *userName != "" && *userName != user.Name {
+			if user.Role != roleAdmin {
+				return fmt.Errorf("only admins can export the feeds of other users")
+			}
+			user, err = s.DB.GetUser(context.Background(), *userName)
+			if err != nil {
+				if err == sql.ErrNoRows {
+					return fmt.Errorf("user '%s' not found", *userName)
+				}
+				return fmt.Errorf("failed to get user: %w", err)
+			}
+		}
+
+		follows, err := s.DB.GetFeedFollowsForUser(context.Background(), user.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get feed follows: %w", err)
+		}
+		tags, err := getFollowTags(s, user)
+		if err != nil {
+			return err
+		}
+		title = fmt.Sprintf("Feeds followed by %s", user.Name)
+		for _, follow := range follows {
+			sub := opml.Subscription{
+				Title:   follow.FeedName,
+				XMLURL:  follow.FeedUrl,
+				HTMLURL: follow.FeedLink.String,
+			}
+			// A feed with several tags is listed once in each folder
+			if len(tags[follow.FeedID]) == 0 {
+				subs = append(subs, sub)
+			}
+			for _, tag := range tags[follow.FeedID] {
+				sub.Folder = tag
+				subs = append(subs, sub)
+			}
+		}
+	}
+
+	// Write to stdout unless a file was given
+	doc := opml.NewDocument(title, subs)
+	if len(args) < 2 || args[1] == "-" {
+		return opml.Write(os.Stdout, doc)
+	}
+
+	f, err := os.Create(args[1])
+	if err != nil {
+		return fmt.Errorf("failed to create OPML file: %w", err)
+	}
+	if err := opml.Write(f, doc); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write OPML file: %w", err)
+	}
+
+	fmt.Printf("Exported %d feed(s) to %s\n", len(subs), args[1])
+	return nil
+}
+
+// readOPML parses an OPML file, reading from stdin when the path is "-"
+func readOPML(path string) (*opml.Document, error) {
+	var r io.Reader = os.Stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open OPML file: %w", err)
+		}
+		defer f.Close()
+		r = f
+	}
+	return opml.Parse(r)
+}
@@ -0,0 +1,94 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/PassZ/rss-aggregator/internal/opml"
+)
+
+func TestImportExport(t *testing.T) {
+	s, server := newTestState(t)
+	registerUser(t, s, "alice")
+	alphaURL, betaURL := server.URL+"/alpha.xml", server.URL+"/beta.xml"
+
+	path := filepath.Join(t.TempDir(), "subscriptions.opml")
+	subscriptions := `<?xml version="1.0" encoding="UTF-8"?>
+<opml version="2.0">
+  <body>
+    <outline text="Tech">
+      <outline text="Databases">
+        <outline text="Alpha" xmlUrl="ALPHA"/>
+      </outline>
+      <outline text="Alpha again" xmlUrl="ALPHA#top"/>
+    </outline>
+    <outline text="Beta" xmlUrl="BETA"/>
+    <outline text="No URL"/>
+  </body>
+</opml>`
+	subscriptions = strings.NewReplacer("ALPHA", alphaURL, "BETA", betaURL).Replace(subscriptions)
+	if err := os.WriteFile(path, []byte(subscriptions), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	out := mustRun(t, s, "import", "opml", path, "--dry-run")
+	assertContains(t, out, "Dry run", "Created: 2, Followed: 0, Skipped: 1, Invalid: 1")
+	if stats := userStats(t, s); stats.FeedCount != 0 {
+		t.Fatalf("a dry run added %d feed(s)", stats.FeedCount)
+	}
+
+	out = mustRun(t, s, "import", "opml", path)
+	assertContains(t, out, "Created: 2, Followed: 0, Skipped: 1, Invalid: 1")
+	if stats := userStats(t, s); stats.FeedCount != 2 || stats.FollowCount != 2 {
+		t.Fatalf("got stats %+v after importing, want 2 feeds followed", stats)
+	}
+	out = mustRun(t, s, "folders")
+	assertContains(t, out, "Tech/Databases (1 feed(s))", "Tech (1 feed(s))")
+
+	// Importing again changes nothing
+	out = mustRun(t, s, "import", "opml", path)
+	assertContains(t, out, "Created: 0, Followed: 0, Skipped: 3, Invalid: 1")
+
+	// Another user follows the feeds that already exist
+	registerUser(t, s, "bob")
+	out = mustRun(t, s, "import", "opml", path)
+	assertContains(t, out, "Created: 0, Followed: 2")
+
+	// Exports keep the folders, listing a feed once in each of them
+	exported := filepath.Join(t.TempDir(), "export.opml")
+	setInput(t, "")
+	mustRun(t, s, "login", "alice")
+	out = mustRun(t, s, "export", "opml", exported)
+	assertContains(t, out, "Exported 3 feed(s)")
+	file, err := os.Open(exported)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	doc, err := opml.Parse(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	folders := make(map[string]string)
+	for _, sub := range doc.Subscriptions() {
+		folders[sub.XMLURL+" in "+sub.Folder] = sub.Title
+	}
+	for _, want := range []string{alphaURL + " in Tech/Databases", alphaURL + " in Tech", betaURL + " in "} {
+		if _, ok := folders[want]; !ok {
+			t.Errorf("export is missing %s, got %v", want, folders)
+		}
+	}
+
+	// Only admins export someone else's feeds
+	out = mustRun(t, s, "export", "opml", "--user", "bob")
+	assertContains(t, out, "Feeds followed by bob", betaURL)
+	setInput(t, "")
+	mustRun(t, s, "login", "bob")
+	if _, err := run(t, s, "export", "opml", "--user", "alice"); err == nil {
+		t.Error("a member exported another user's feeds")
+	}
+	out = mustRun(t, s, "export", "opml", "--all")
+	assertContains(t, out, "All gator feeds", alphaURL, betaURL)
+}
@@ -0,0 +1,92 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/PassZ/rss-aggregator/internal/config"
+)
+
+// HandlerProfile handles the profile command and its subcommands. Profiles only live in
+// the config file, so it runs without a database.
+func HandlerProfile(s *State, cmd Command) error {
+	usage := fmt.Errorf("usage: profile [list|use|add|remove]")
+	if len(cmd.Args) == 0 {
+		return profileList(s)
+	}
+
+	switch cmd.Args[0] {
+	case "list":
+		return profileList(s)
+	case "use":
+		if len(cmd.Args) != 2 {
+			return fmt.Errorf("usage: profile use <name>")
+		}
+		return profileUse(s, cmd.Args[1])
+	case "add":
+		if len(cmd.Args) != 3 {
+			return fmt.Errorf("usage: profile add <name> <db_url>")
+		}
+		return profileAdd(s, cmd.Args[1], cmd.Args[2])
+	case "remove":
+		if len(cmd.Args) != 2 {
+			return fmt.Errorf("usage: profile remove <name>")
+		}
+		return profileRemove(s, cmd.Args[1])
+	default:
+		return usage
+	}
+}
+
+// profileList prints every profile with its database, marking the active one
+func profileList(s *State) error {
+	fmt.Printf("Profiles in %s:\n", s.Config.Path)
+	for _, name := range s.Config.ProfileNames() {
+		profile, _ := s.Config.GetProfile(name)
+		marker := " "
+		if name == s.Config.ProfileName {
+			marker = "*"
+		}
+		dbURL := "(no db_url)"
+		if profile.DbURL != "" {
+			dbURL = config.RedactedDbURL(profile.DbURL)
+		}
+		fmt.Printf("%s %s: %s\n", marker, name, dbURL)
+	}
+	return nil
+}
+
+// profileUse switches to another profile for later commands
+func profileUse(s *State, name string) error {
+	if err := s.Config.UseProfile(name); err != nil {
+		return err
+	}
+
+	profile, _ := s.Config.GetProfile(name)
+	if profile.DbURL == "" {
+		fmt.Printf("Using profile %s, which has no db_url yet\n", name)
+		return nil
+	}
+	fmt.Printf("Using profile %s (%s)\n", name, config.RedactedDbURL(profile.DbURL))
+	if profile.SessionToken == "" {
+		fmt.Println("You are not logged in on this profile, use 'gator login <name>'")
+	}
+	return nil
+}
+
+// profileAdd saves a profile for another database
+func profileAdd(s *State, name, dbURL string) error {
+	if err := s.Config.AddProfile(name, dbURL); err != nil {
+		return err
+	}
+	fmt.Printf("Added profile %s, switch to it with 'gator profile use %s'\n", name, name)
+	return nil
+}
+
+// profileRemove deletes a profile that is not in use
+func profileRemove(s *State, name string) error {
+	if err := s.Config.RemoveProfile(name); err != nil {
+		return err
+	}
+	fmt.Printf("Removed profile %s\n", name)
+	return nil
+}
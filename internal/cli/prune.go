@@ -0,0 +1,100 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/PassZ/rss-aggregator/internal/database"
+)
+
+// HandlerPrune handles the prune command
+func HandlerPrune(s *State, cmd Command, user database.User) error {
+	fs := newFlagSet(cmd)
+	dryRun := fs.Bool("dry-run", false, "list the posts that would be pruned without deleting them")
+	if _, err := parseFlags(fs, cmd.Args); err != nil {
+		return err
+	}
+
+	if *dryRun {
+		fmt.Println("Dry run: no posts will be deleted.")
+	}
+
+	pruned, err := prunePosts(s, *dryRun)
+	if err != nil {
+		return err
+	}
+
+	if len(pruned) == 0 {
+		fmt.Println("No posts to prune.")
+		return nil
+	}
+
+	// Print the pruned posts grouped by feed
+	var feedName string
+	for _, post := range pruned {
+		if post.FeedName != feedName {
+			feedName = post.FeedName
+			fmt.Printf("%s:\n", feedName)
+		}
+		fmt.Printf("  - %s\n", post.Title)
+	}
+
+	if *dryRun {
+		fmt.Printf("\n%d post(s) would be pruned\n", len(pruned))
+	} else {
+		fmt.Printf("\nPruned %d post(s)\n", len(pruned))
+	}
+	return nil
+}
+
+// prunePosts deletes the posts past their retention policy, leaving a tombstone for each so
+// the next fetch doesn't insert them again. With dryRun it only returns what it would delete.
+func prunePosts(s *State, dryRun bool) ([]database.GetPrunablePostsRow, error) {
+	posts, err := s.DB.GetPrunablePosts(context.Background(), database.GetPrunablePostsParams{
+		DefaultMaxAgeDays: int32(s.Config.RetentionMaxAgeDays),
+		Now:               time.Now().UTC(),
+		DefaultMaxPosts:   int32(s.Config.RetentionMaxPosts),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get posts to prune: %w", err)
+	}
+	if dryRun {
+		return posts, nil
+	}
+
+	// Delete the batch in one transaction so a failure never leaves a post deleted without
+	// its tombstone, which would have the next fetch insert it again
+	tx, err := s.DB.BeginTx(context.Background(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, post := range posts {
+		// Items are identified the same way processPost checks them
+		key := strings.TrimSpace(post.Url)
+		if post.Guid.Valid {
+			key = post.Guid.String
+		}
+		err := tx.CreatePostTombstone(context.Background(), database.CreatePostTombstoneParams{
+			FeedID:   post.FeedID,
+			Guid:     key,
+			PrunedAt: time.Now().UTC(),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to record pruned post: %w", err)
+		}
+
+		err = tx.DeletePost(context.Background(), post.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to delete post: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit prune: %w", err)
+	}
+	return posts, nil
+}
\ No newline at end of file
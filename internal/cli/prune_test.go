@@ -0,0 +1,31 @@
+package cli
+
+import "testing"
+
+func TestPrune(t *testing.T) {
+	s, server := newTestState(t)
+	registerUser(t, s, "alice")
+	mustRun(t, s, "addfeed", server.URL+"/alpha.xml")
+	aggregate(t, s)
+
+	s.Config.RetentionMaxPosts = 1
+	out := mustRun(t, s, "prune", "--dry-run")
+	assertContains(t, out, "2 post(s) would be pruned")
+	if stats := userStats(t, s); stats.PostCount != 3 {
+		t.Fatalf("dry run deleted posts, %d left", stats.PostCount)
+	}
+
+	// The newest post is kept
+	mustRun(t, s, "prune")
+	if stats := userStats(t, s); stats.PostCount != 1 {
+		t.Fatalf("got %d post(s) after pruning, want 1", stats.PostCount)
+	}
+	out = mustRun(t, s, "browse")
+	assertContains(t, out, "Postgres performance")
+
+	// Pruned posts are not fetched again
+	aggregate(t, s)
+	if stats := userStats(t, s); stats.PostCount != 1 {
+		t.Errorf("got %d post(s) after fetching again, want 1", stats.PostCount)
+	}
+}
@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/PassZ/rss-aggregator/internal/database"
+)
+
+// dateFormats are the layouts accepted for date arguments
+var dateFormats = []string{
+	time.RFC3339,
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// HandlerMarkRead handles the markread command
+func HandlerMarkRead(s *State, cmd Command, user database.User) error {
+	fs := newFlagSet(cmd)
+	all := fs.Bool("all", false, "mark every post of the feeds you follow as read")
+	feedURL := fs.String("feed", "", "only mark posts of this feed as read")
+	before := fs.String("before", "", "only mark posts published before this date as read")
+	args, err := parseFlags(fs, cmd.Args)
+	if err != nil {
+		return err
+	}
+
+	// Either a single post or a selection of posts must be given
+	usage := fmt.Errorf("usage: markread <post-id> | --all | [--feed url] [--before date]")
+	if len(args) > 0 && (*all || *feedURL != "" || *before != "") {
+		return usage
+	}
+	if len(args) == 0 && !*all && *feedURL == "" && *before == "" {
+		return usage
+	}
+
+	params := database.MarkPostsReadParams{
+		ReadAt: time.Now().UTC(),
+		UserID: user.ID,
+	}
+	if len(args) > 0 {
+		postID, err := uuid.Parse(args[0])
+		if err != nil {
+			return fmt.Errorf("invalid post ID: %s", args[0])
+		}
+		params.PostID = uuid.NullUUID{UUID: postID, Valid: true}
+	}
+	if *feedURL != "" {
+		feed, err := findFeedByURL(s, *feedURL)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return fmt.Errorf("feed with URL '%s' not found", *feedURL)
+			}
+			return fmt.Errorf("failed to get feed: %w", err)
+		}
+		params.FeedID = uuid.NullUUID{UUID: feed.ID, Valid: true}
+	}
+	if *before != "" {
+		date, err := parseDate(*before, userLocation(user))
+		if err != nil {
+			return err
+		}
+		params.Before = sql.NullTime{Time: date, Valid: true}
+	}
+
+	marked, err := s.DB.MarkPostsRead(context.Background(), params)
+	if err != nil {
+		return fmt.Errorf("failed to mark posts as read: %w", err)
+	}
+
+	if params.PostID.Valid && marked == 0 {
+		fmt.Println("Post was already read or is not in a feed you follow.")
+		return nil
+	}
+	fmt.Printf("Marked %d post(s) as read\n", marked)
+	return nil
+}
+
+// parseDate parses a date argument in one of the accepted layouts, in the given timezone
+func parseDate(value string, loc *time.Location) (time.Time, error) {
+	for _, format := range dateFormats {
+		if date, err := time.ParseInLocation(format, value, loc); err == nil {
+			return date, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date '%s', use YYYY-MM-DD or RFC 3339", value)
+}
@@ -0,0 +1,82 @@
+package cli
+
+import (
+	"context"
+	"testing"
+)
+
+func TestReset(t *testing.T) {
+	s, server := newTestState(t)
+	setInput(t, "")
+	mustRun(t, s, "register", "alice")
+	alphaURL := server.URL + "/alpha.xml"
+	mustRun(t, s, "addfeed", alphaURL)
+	aggregate(t, s)
+	registerUser(t, s, "bob")
+	mustRun(t, s, "follow", alphaURL)
+	mustRun(t, s, "markread", "--all")
+	mustRun(t, s, "star", postID(t, s, "Gardening tips").String())
+	mustRun(t, s, "rules", "add", "postgres", "--action", "tag", "--tag", "db")
+	mustRun(t, s, "tag", alphaURL, "reading")
+
+	// Only admins reset
+	if _, err := run(t, s, "reset", "--yes"); err == nil {
+		t.Fatal("a member reset the database")
+	}
+
+	setInput(t, "")
+	mustRun(t, s, "login", "alice")
+	if _, err := run(t, s, "reset", "--posts", "--feeds", "--yes"); err == nil {
+		t.Error("reset with two scopes succeeded")
+	}
+	setInput(t, "n")
+	mustRun(t, s, "reset")
+	if len(backups(t)) != 0 {
+		t.Error("an aborted reset wrote a backup")
+	}
+
+	// A user reset clears their state but keeps the account and everyone else's
+	mustRun(t, s, "reset", "--user", "bob", "--yes")
+	bob := getUser(t, s, "bob")
+	stats, err := s.DB.GetUserStats(context.Background(), bob.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.FollowCount != 0 || stats.StarCount != 0 || stats.UnreadCount != 0 {
+		t.Errorf("got stats %+v after resetting bob, want nothing left", stats)
+	}
+	rules, err := s.DB.GetRulesForUser(context.Background(), bob.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rules) != 0 {
+		t.Errorf("bob has %d rule(s) after the reset, want 0", len(rules))
+	}
+	if stats := userStats(t, s); stats.FollowCount != 1 || stats.PostCount != 3 {
+		t.Errorf("got alice's stats %+v after resetting bob, want them untouched", stats)
+	}
+
+	mustRun(t, s, "reset", "--posts", "--yes")
+	if stats := userStats(t, s); stats.PostCount != 0 || stats.FeedCount != 1 {
+		t.Errorf("got stats %+v after deleting posts, want the feed without posts", stats)
+	}
+	mustRun(t, s, "reset", "--feeds", "--yes")
+	if stats := userStats(t, s); stats.FeedCount != 0 || stats.FollowCount != 0 {
+		t.Errorf("got stats %+v after deleting feeds, want none", stats)
+	}
+
+	out := mustRun(t, s, "reset", "--yes")
+	assertContains(t, out, "all users deleted")
+	users, err := s.DB.GetUsers(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(users) != 0 {
+		t.Errorf("got %d user(s) after a full reset, want 0", len(users))
+	}
+
+	// Every reset that ran left a backup behind
+	if got := len(backups(t)); got != 4 {
+		t.Errorf("got %d backup(s), want one for each of the 4 resets", got)
+	}
+}
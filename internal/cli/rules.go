@@ -0,0 +1,332 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/PassZ/rss-aggregator/internal/database"
+	"github.com/PassZ/rss-aggregator/internal/rules"
+)
+
+// compiledRule is a stored rule together with its compiled matcher
+type compiledRule struct {
+	database.Rule
+	matcher *rules.Matcher
+	// feedName is the name the rule's owner follows the feed under, set for rules run on
+	// the posts of a single feed as they are fetched
+	feedName string
+}
+
+// HandlerRules handles the rules command and its subcommands
+func HandlerRules(s *State, cmd Command, user database.User) error {
+	usage := fmt.Errorf("usage: rules add|list|remove|test|apply")
+	if len(cmd.Args) == 0 {
+		return usage
+	}
+
+	sub := Command{Name: "rules " + cmd.Args[0], Args: cmd.Args[1:]}
+	switch cmd.Args[0] {
+	case "add":
+		return rulesAdd(s, sub, user)
+	case "list":
+		return rulesList(s, user)
+	case "remove":
+		return rulesRemove(s, sub, user)
+	case "test":
+		return rulesTest(s, sub, user)
+	case "apply":
+		return rulesApply(s, sub, user)
+	default:
+		return usage
+	}
+}
+
+// rulesAdd creates a rule for the user
+func rulesAdd(s *State, cmd Command, user database.User) error {
+	fs := newFlagSet(cmd)
+	field := fs.String("field", rules.FieldTitle, "post field to match: title, description, author, category or feed")
+	match := fs.String("match", rules.MatchSubstring, "how to match: substring, regex or glob")
+	action := fs.String("action", "", "what to do with matching posts: hide, mark-read, star or tag")
+	tag := fs.String("tag", "", "tag to add with the tag action")
+	args, err := parseFlags(fs, cmd.Args)
+	if err != nil {
+		return err
+	}
+
+	// Check if the command has the required arguments
+	if len(args) != 1 || *action == "" {
+		return fmt.Errorf("usage: rules add <pattern> --action hide|mark-read|star|tag [--tag name] [--field title] [--match substring]")
+	}
+	if _, err := rules.NewMatcher(*field, *match, args[0]); err != nil {
+		return err
+	}
+	if *tag != "" {
+		if *tag, err = normalizeTag(*tag); err != nil {
+			return err
+		}
+	}
+	if err := rules.ValidateAction(*action, *tag); err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	rule, err := s.DB.CreateRule(context.Background(), database.CreateRuleParams{
+		ID:        uuid.New(),
+		CreatedAt: now,
+		UpdatedAt: now,
+		UserID:    user.ID,
+		Field:     *field,
+		MatchType: *match,
+		Pattern:   args[0],
+		Action:    *action,
+		Tag:       nullString(*tag),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create rule: %w", err)
+	}
+
+	fmt.Printf("Rule created: %s\n", describeRule(rule))
+	fmt.Printf("  ID: %s\n", rule.ID)
+	fmt.Println("It applies to new posts; run 'gator rules apply' to apply it to existing ones.")
+	return nil
+}
+
+// rulesList prints the user's rules
+func rulesList(s *State, user database.User) error {
+	list, err := s.DB.GetRulesForUser(context.Background(), user.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get rules: %w", err)
+	}
+
+	if len(list) == 0 {
+		fmt.Println("You have no rules.")
+		return nil
+	}
+
+	fmt.Printf("You have %d rule(s):\n\n", len(list))
+	for i, rule := range list {
+		fmt.Printf("%d. %s\n", i+1, describeRule(rule))
+		fmt.Printf("   ID: %s\n", rule.ID)
+		fmt.Println()
+	}
+	return nil
+}
+
+// rulesRemove deletes one of the user's rules
+func rulesRemove(s *State, cmd Command, user database.User) error {
+	if len(cmd.Args) == 0 {
+		return fmt.Errorf("usage: rules remove <rule-id>")
+	}
+	id, err := uuid.Parse(cmd.Args[0])
+	if err != nil {
+		return fmt.Errorf("invalid rule ID: %s", cmd.Args[0])
+	}
+
+	removed, err := s.DB.DeleteRule(context.Background(), database.DeleteRuleParams{
+		ID:     id,
+		UserID: user.ID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to remove rule: %w", err)
+	}
+	if removed == 0 {
+		return fmt.Errorf("rule '%s' not found", cmd.Args[0])
+	}
+
+	// Hidden posts come back through ON DELETE CASCADE, other actions stay
+	fmt.Println("Rule removed")
+	return nil
+}
+
+// rulesTest lists existing posts a pattern would match, without changing anything
+func rulesTest(s *State, cmd Command, user database.User) error {
+	fs := newFlagSet(cmd)
+	field := fs.String("field", rules.FieldTitle, "post field to match: title, description, author, category or feed")
+	match := fs.String("match", rules.MatchSubstring, "how to match: substring, regex or glob")
+	limit := fs.Int("limit", 10, "maximum number of matching posts to show")
+	args, err := parseFlags(fs, cmd.Args)
+	if err != nil {
+		return err
+	}
+
+	// Check if the command has the required argument
+	if len(args) != 1 {
+		return fmt.Errorf("usage: rules test <pattern> [--field title] [--match substring] [--limit 10]")
+	}
+	matcher, err := rules.NewMatcher(*field, *match, args[0])
+	if err != nil {
+		return err
+	}
+
+	posts, err := s.DB.GetPostsForRules(context.Background(), user.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get posts: %w", err)
+	}
+
+	matched := 0
+	for _, post := range posts {
+		if !matcher.Matches(rulesPost(post)) {
+			continue
+		}
+		matched++
+		if matched <= *limit {
+			fmt.Printf("%d. %s\n", matched, post.Title)
+			fmt.Printf("   ID: %s\n", post.ID)
+			fmt.Printf("   Feed: %s\n", post.FeedName)
+			fmt.Println()
+		}
+	}
+
+	fmt.Printf("%d of %d post(s) match\n", matched, len(posts))
+	return nil
+}
+
+// rulesApply runs the user's rules, or a single rule, over the posts already fetched
+func rulesApply(s *State, cmd Command, user database.User) error {
+	list, err := s.DB.GetRulesForUser(context.Background(), user.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get rules: %w", err)
+	}
+
+	// Narrow down to a single rule when one is given
+	if len(cmd.Args) > 0 {
+		id, err := uuid.Parse(cmd.Args[0])
+		if err != nil {
+			return fmt.Errorf("invalid rule ID: %s", cmd.Args[0])
+		}
+		var selected []database.Rule
+		for _, rule := range list {
+			if rule.ID == id {
+				selected = append(selected, rule)
+			}
+		}
+		if len(selected) == 0 {
+			return fmt.Errorf("rule '%s' not found", cmd.Args[0])
+		}
+		list = selected
+	}
+	if len(list) == 0 {
+		fmt.Println("You have no rules.")
+		return nil
+	}
+
+	posts, err := s.DB.GetPostsForRules(context.Background(), user.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get posts: %w", err)
+	}
+
+	compiled := compileRules(list)
+	counts := make(map[uuid.UUID]int)
+	for _, post := range posts {
+		for _, rule := range compiled {
+			if !rule.matcher.Matches(rulesPost(post)) {
+				continue
+			}
+			if err := applyRule(s, rule.Rule, post.ID); err != nil {
+				return err
+			}
+			counts[rule.ID]++
+		}
+	}
+
+	for _, rule := range compiled {
+		fmt.Printf("%s: %d post(s)\n", describeRule(rule.Rule), counts[rule.ID])
+	}
+	return nil
+}
+
+// compileRules builds the matchers of stored rules, skipping any that no longer compile
+func compileRules(list []database.Rule) []compiledRule {
+	var compiled []compiledRule
+	for _, rule := range list {
+		if c, ok := compileRule(rule); ok {
+			compiled = append(compiled, c)
+		}
+	}
+	return compiled
+}
+
+// compileFeedRules builds the matchers of the rules run on a feed's new posts, skipping any
+// that no longer compile
+func compileFeedRules(list []database.GetRulesForFeedRow) []compiledRule {
+	var compiled []compiledRule
+	for _, row := range list {
+		if c, ok := compileRule(row.Rule); ok {
+			c.feedName = row.FeedName
+			compiled = append(compiled, c)
+		}
+	}
+	return compiled
+}
+
+// compileRule builds the matcher of a stored rule, reporting false when it no longer compiles
+func compileRule(rule database.Rule) (compiledRule, bool) {
+	matcher, err := rules.NewMatcher(rule.Field, rule.MatchType, rule.Pattern)
+	if err != nil {
+		fmt.Printf("Skipping rule %s: %v\n", rule.ID, err)
+		return compiledRule{}, false
+	}
+	return compiledRule{Rule: rule, matcher: matcher}, true
+}
+
+// applyRule takes a rule's action on a post for the rule's owner
+func applyRule(s *State, rule database.Rule, postID uuid.UUID) error {
+	var err error
+	switch rule.Action {
+	case rules.ActionHide:
+		err = s.DB.HidePost(context.Background(), database.HidePostParams{
+			UserID:   rule.UserID,
+			PostID:   postID,
+			RuleID:   rule.ID,
+			HiddenAt: time.Now().UTC(),
+		})
+	case rules.ActionMarkRead:
+		_, err = s.DB.MarkPostsRead(context.Background(), database.MarkPostsReadParams{
+			ReadAt: time.Now().UTC(),
+			UserID: rule.UserID,
+			PostID: uuid.NullUUID{UUID: postID, Valid: true},
+		})
+	case rules.ActionStar:
+		_, err = s.DB.StarPost(context.Background(), database.StarPostParams{
+			ID:        uuid.New(),
+			CreatedAt: time.Now().UTC(),
+			UserID:    rule.UserID,
+			PostID:    postID,
+		})
+	case rules.ActionTag:
+		err = s.DB.TagPost(context.Background(), database.TagPostParams{
+			UserID:    rule.UserID,
+			PostID:    postID,
+			Tag:       rule.Tag.String,
+			CreatedAt: time.Now().UTC(),
+		})
+	default:
+		return fmt.Errorf("rule %s has unknown action '%s'", rule.ID, rule.Action)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to apply rule %s: %w", rule.ID, err)
+	}
+	return nil
+}
+
+// rulesPost converts a stored post into the fields rules match against
+func rulesPost(post database.GetPostsForRulesRow) rules.Post {
+	return rules.Post{
+		Title:       post.Title,
+		Description: post.Description.String,
+		Author:      post.Author.String,
+		Categories:  rules.SplitCategories(post.Categories.String),
+		Feed:        post.FeedName,
+	}
+}
+
+// describeRule summarizes a rule on one line
+func describeRule(rule database.Rule) string {
+	action := rule.Action
+	if rule.Tag.Valid {
+		action += " " + rule.Tag.String
+	}
+	return fmt.Sprintf("%s when %s %s %q", action, rule.Field, rule.MatchType, rule.Pattern)
+}
\ No newline at end of file
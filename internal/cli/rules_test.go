@@ -0,0 +1,30 @@
+package cli
+
+import "testing"
+
+func TestRulesApply(t *testing.T) {
+	s, server := newTestState(t)
+	registerUser(t, s, "alice")
+	mustRun(t, s, "addfeed", server.URL+"/alpha.xml")
+	aggregate(t, s)
+
+	// Rules run over posts fetched before they existed when applied
+	mustRun(t, s, "rules", "add", "postgres", "--action", "star")
+	out := mustRun(t, s, "rules", "apply")
+	assertContains(t, out, "1 post(s)")
+	out = mustRun(t, s, "starred")
+	assertContains(t, out, "Postgres performance")
+	assertNotContains(t, out, "Gardening tips")
+
+	// and over new posts as they are fetched
+	mustRun(t, s, "rules", "add", "roundup", "--action", "mark-read")
+	mustRun(t, s, "addfeed", server.URL+"/beta.xml")
+	aggregate(t, s)
+	stats := userStats(t, s)
+	if stats.StarCount != 2 {
+		t.Errorf("got %d starred post(s), want 2", stats.StarCount)
+	}
+	if stats.UnreadCount != 4 {
+		t.Errorf("got %d unread post(s), want 4", stats.UnreadCount)
+	}
+}
@@ -0,0 +1,96 @@
+package cli
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/PassZ/rss-aggregator/internal/database"
+)
+
+// htmlTagPattern matches markup left in search snippets taken from HTML descriptions
+var htmlTagPattern = regexp.MustCompile(`<[^>]*>`)
+
+// HandlerSearch handles the search command
+func HandlerSearch(s *State, cmd Command, user database.User) error {
+	fs := newFlagSet(cmd)
+	feedURL := fs.String("feed", "", "only search posts of this feed")
+	since := fs.String("since", "", "only search posts published on or after this date")
+	limit := fs.Int("limit", 10, "maximum number of results")
+	all := fs.Bool("all", false, "search every feed, not only the ones you follow")
+	args, err := parseFlags(fs, cmd.Args)
+	if err != nil {
+		return err
+	}
+
+	// Check if the command has the required argument
+	if len(args) == 0 {
+		return fmt.Errorf("usage: search \"<query>\" [--feed url] [--since date] [--limit n] [--all]")
+	}
+	if *limit <= 0 || *limit > maxPageSize {
+		return fmt.Errorf("invalid limit %d, use a number from 1 to %d", *limit, maxPageSize)
+	}
+
+	params := database.SearchPostsParams{
+		Query:    strings.Join(args, " "),
+		AllFeeds: *all,
+		UserID:   user.ID,
+		Limit:    int32(*limit),
+	}
+	if *feedURL != "" {
+		feed, err := findFeedByURL(s, *feedURL)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return fmt.Errorf("feed with URL '%s' not found", *feedURL)
+			}
+			return fmt.Errorf("failed to get feed: %w", err)
+		}
+		params.FeedID = uuid.NullUUID{UUID: feed.ID, Valid: true}
+	}
+	if *since != "" {
+		date, err := parseDate(*since, userLocation(user))
+		if err != nil {
+			return err
+		}
+		params.Since = sql.NullTime{Time: date, Valid: true}
+	}
+
+	results, err := s.DB.SearchPosts(context.Background(), params)
+	if err != nil {
+		return fmt.Errorf("failed to search posts: %w", err)
+	}
+
+	// Check if there are any results
+	if len(results) == 0 {
+		fmt.Printf("No posts match \"%s\".\n", params.Query)
+		return nil
+	}
+
+	// Print results, best match first
+	fmt.Printf("Found %d post(s) matching \"%s\":\n\n", len(results), params.Query)
+	for i, result := range results {
+		fmt.Printf("%d. %s\n", i+1, result.Title)
+		fmt.Printf("   ID: %s\n", result.ID)
+		fmt.Printf("   Feed: %s\n", result.FeedName)
+		fmt.Printf("   URL: %s\n", result.Url)
+		if snippet := cleanSnippet(result.Snippet); snippet != "" {
+			fmt.Printf("   ...%s...\n", snippet)
+		}
+		if result.PublishedAt.Valid {
+			fmt.Printf("   Published: %s\n", formatTime(result.PublishedAt.Time, userLocation(user)))
+		}
+		fmt.Println()
+	}
+
+	return nil
+}
+
+// cleanSnippet strips markup from a search snippet and collapses its whitespace
+func cleanSnippet(snippet string) string {
+	snippet = html.UnescapeString(htmlTagPattern.ReplaceAllString(snippet, " "))
+	return strings.Join(strings.Fields(snippet), " ")
+}
@@ -0,0 +1,113 @@
+package cli
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/PassZ/rss-aggregator/internal/database"
+)
+
+// HandlerStar handles the star command
+func HandlerStar(s *State, cmd Command, user database.User) error {
+	fs := newFlagSet(cmd)
+	note := fs.String("note", "", "a note to keep with the starred post")
+	args, err := parseFlags(fs, cmd.Args)
+	if err != nil {
+		return err
+	}
+
+	// Check if the command has the required argument
+	if len(args) == 0 {
+		return fmt.Errorf("usage: star <post-id> [--note text]")
+	}
+	postID, err := uuid.Parse(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid post ID: %s", args[0])
+	}
+
+	// Star the post, keeping a copy so it survives the post being deleted
+	star, err := s.DB.StarPost(context.Background(), database.StarPostParams{
+		ID:        uuid.New(),
+		CreatedAt: time.Now().UTC(),
+		UserID:    user.ID,
+		Note:      nullString(*note),
+		PostID:    postID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("post '%s' not found", args[0])
+		}
+		return fmt.Errorf("failed to star post: %w", err)
+	}
+
+	fmt.Printf("Starred: %s\n", star.Title)
+	if star.Note.Valid {
+		fmt.Printf("  Note: %s\n", star.Note.String)
+	}
+	return nil
+}
+
+// HandlerUnstar handles the unstar command
+func HandlerUnstar(s *State, cmd Command, user database.User) error {
+	// Check if the command has the required argument
+	if len(cmd.Args) == 0 {
+		return fmt.Errorf("usage: unstar <post-id>")
+	}
+	id, err := uuid.Parse(cmd.Args[0])
+	if err != nil {
+		return fmt.Errorf("invalid post ID: %s", cmd.Args[0])
+	}
+
+	// The ID may be the post's or, for posts that no longer exist, the star's
+	removed, err := s.DB.UnstarPost(context.Background(), database.UnstarPostParams{
+		UserID: user.ID,
+		ID:     id,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to unstar post: %w", err)
+	}
+	if removed == 0 {
+		return fmt.Errorf("post '%s' is not starred", cmd.Args[0])
+	}
+
+	fmt.Println("Post unstarred")
+	return nil
+}
+
+// HandlerStarred handles the starred command
+func HandlerStarred(s *State, cmd Command, user database.User) error {
+	stars, err := s.DB.GetStarredPosts(context.Background(), user.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get starred posts: %w", err)
+	}
+
+	// Check if there are any starred posts
+	if len(stars) == 0 {
+		fmt.Println("You have no starred posts.")
+		return nil
+	}
+
+	// Print starred posts
+	fmt.Printf("You have %d starred post(s):\n\n", len(stars))
+	for i, star := range stars {
+		fmt.Printf("%d. %s\n", i+1, star.Title)
+		if star.PostID.Valid {
+			fmt.Printf("   ID: %s\n", star.PostID.UUID)
+		} else {
+			// The post's feed was deleted, only our copy is left
+			fmt.Printf("   ID: %s (post no longer available)\n", star.ID)
+		}
+		fmt.Printf("   Feed: %s\n", star.FeedName)
+		fmt.Printf("   URL: %s\n", star.PostUrl)
+		if star.Note.Valid {
+			fmt.Printf("   Note: %s\n", star.Note.String)
+		}
+		fmt.Printf("   Starred at: %s\n", formatTime(star.CreatedAt, userLocation(user)))
+		fmt.Println()
+	}
+
+	return nil
+}
@@ -0,0 +1,52 @@
+package cli
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestStarUnstar(t *testing.T) {
+	s, server := newTestState(t)
+	registerUser(t, s, "alice")
+	alphaURL := server.URL + "/alpha.xml"
+	mustRun(t, s, "addfeed", alphaURL)
+	aggregate(t, s)
+
+	out := mustRun(t, s, "starred")
+	assertContains(t, out, "You have no starred posts.")
+
+	id := postID(t, s, "Gardening tips").String()
+	out = mustRun(t, s, "star", id, "--note", "for the weekend")
+	assertContains(t, out, "Starred: Gardening tips", "Note: for the weekend")
+	out = mustRun(t, s, "starred")
+	assertContains(t, out, "1 starred post(s)", "Gardening tips", "ID: "+id, "Note: for the weekend")
+	out = mustRun(t, s, "browse")
+	assertContains(t, out, "[starred]")
+
+	if _, err := run(t, s, "star", "not-an-id"); err == nil {
+		t.Error("starred an invalid post ID")
+	}
+	if _, err := run(t, s, "star", uuid.NewString()); err == nil {
+		t.Error("starred a post that doesn't exist")
+	}
+
+	// Stars outlive their post, and are then removed by their own ID
+	mustRun(t, s, "deletefeed", alphaURL, "--yes")
+	out = mustRun(t, s, "starred")
+	assertContains(t, out, "Gardening tips", "(post no longer available)")
+	stars, err := s.DB.GetStarredPosts(context.Background(), loggedInUser(t, s).ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stars) != 1 {
+		t.Fatalf("got %d star(s), want 1", len(stars))
+	}
+	mustRun(t, s, "unstar", stars[0].ID.String())
+	out = mustRun(t, s, "starred")
+	assertContains(t, out, "You have no starred posts.")
+	if _, err := run(t, s, "unstar", stars[0].ID.String()); err == nil {
+		t.Error("unstarred a post that isn't starred")
+	}
+}
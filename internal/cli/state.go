@@ -2,13 +2,16 @@ package cli
 
 import (
 	"github.com/PassZ/rss-aggregator/internal/config"
-	"github.com/PassZ/rss-aggregator/internal/database"
+	"github.com/PassZ/rss-aggregator/internal/rss"
+	"github.com/PassZ/rss-aggregator/internal/store"
 )
 
 // State holds the application state
 type State struct {
-	DB     *database.Queries
+	DB     store.Store
 	Config *config.Config
+	// Fetcher downloads feeds and icons
+	Fetcher rss.Fetcher
 }
 
 // Command represents a CLI command with its name and arguments
@@ -0,0 +1,173 @@
+package cli
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/PassZ/rss-aggregator/internal/database"
+)
+
+// HandlerTag handles the tag command
+func HandlerTag(s *State, cmd Command, user database.User) error {
+	fs := newFlagSet(cmd)
+	remove := fs.Bool("remove", false, "remove the tags instead of adding them")
+	args, err := parseFlags(fs, cmd.Args)
+	if err != nil {
+		return err
+	}
+
+	// Check if the command has the required arguments
+	if len(args) == 0 || (*remove && len(args) < 2) {
+		return fmt.Errorf("usage: tag <feed-url> [tag...] [--remove]")
+	}
+
+	follow, feed, err := getFollow(s, user, args[0])
+	if err != nil {
+		return err
+	}
+
+	// Normalize every tag before changing anything
+	var tags []string
+	for _, arg := range args[1:] {
+		tag, err := normalizeTag(arg)
+		if err != nil {
+			return err
+		}
+		tags = append(tags, tag)
+	}
+
+	for _, tag := range tags {
+		if *remove {
+			removed, err := s.DB.RemoveFeedFollowTag(context.Background(), database.RemoveFeedFollowTagParams{
+				FeedFollowID: follow.ID,
+				Tag:          tag,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to remove tag: %w", err)
+			}
+			if removed == 0 {
+				fmt.Printf("%s is not tagged %s\n", feed.Name, tag)
+				continue
+			}
+			fmt.Printf("Removed tag %s from %s\n", tag, feed.Name)
+			continue
+		}
+
+		err := s.DB.AddFeedFollowTag(context.Background(), database.AddFeedFollowTagParams{
+			FeedFollowID: follow.ID,
+			Tag:          tag,
+			CreatedAt:    time.Now().UTC(),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to add tag: %w", err)
+		}
+		fmt.Printf("Tagged %s with %s\n", feed.Name, tag)
+	}
+
+	// Without tags to change, just list the current ones
+	if len(tags) == 0 {
+		followTags, err := getFollowTags(s, user)
+		if err != nil {
+			return err
+		}
+		if len(followTags[feed.ID]) == 0 {
+			fmt.Printf("%s has no tags.\n", feed.Name)
+			return nil
+		}
+		fmt.Printf("%s is tagged: %s\n", feed.Name, strings.Join(followTags[feed.ID], ", "))
+	}
+
+	return nil
+}
+
+// HandlerFolders handles the folders command
+func HandlerFolders(s *State, cmd Command, user database.User) error {
+	tags, err := s.DB.GetTagsForUser(context.Background(), user.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get tags: %w", err)
+	}
+
+	if len(tags) == 0 {
+		fmt.Println("You have no folders yet. Use 'gator tag <feed-url> <folder>' to create one.")
+		return nil
+	}
+
+	// Sorting by path keeps nested folders right below their parent
+	fmt.Printf("You have %d folder(s):\n\n", len(tags))
+	for _, tag := range tags {
+		fmt.Printf("  %s (%d feed(s))\n", tag.Tag, tag.FeedCount)
+	}
+
+	return nil
+}
+
+// getFollow looks up a feed by URL along with the user's follow of it
+func getFollow(s *State, user database.User, feedURL string) (database.FeedFollow, database.Feed, error) {
+	feed, err := findFeedByURL(s, feedURL)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return database.FeedFollow{}, database.Feed{}, fmt.Errorf("feed with URL '%s' not found", feedURL)
+		}
+		return database.FeedFollow{}, database.Feed{}, fmt.Errorf("failed to get feed: %w", err)
+	}
+
+	follow, err := s.DB.GetFeedFollow(context.Background(), database.GetFeedFollowParams{
+		UserID: user.ID,
+		FeedID: feed.ID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return database.FeedFollow{}, database.Feed{}, fmt.Errorf("you are not following %s", feed.Name)
+		}
+		return database.FeedFollow{}, database.Feed{}, fmt.Errorf("failed to get feed follow: %w", err)
+	}
+
+	return follow, feed, nil
+}
+
+// getFollowTags returns the tags of every feed the user follows, keyed by feed ID
+func getFollowTags(s *State, user database.User) (map[uuid.UUID][]string, error) {
+	rows, err := s.DB.GetFeedFollowTagsForUser(context.Background(), user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tags: %w", err)
+	}
+
+	tags := make(map[uuid.UUID][]string)
+	for _, row := range rows {
+		tags[row.FeedID] = append(tags[row.FeedID], row.Tag)
+	}
+	return tags, nil
+}
+
+// normalizeTag cleans up a tag or folder path, where "/" separates nested folders
+func normalizeTag(tag string) (string, error) {
+	var names []string
+	for _, name := range strings.Split(tag, "/") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return "", fmt.Errorf("invalid tag: '%s'", tag)
+	}
+
+	normalized := strings.Join(names, "/")
+	if len(normalized) > 255 {
+		return "", fmt.Errorf("tag is longer than 255 characters: %s", normalized)
+	}
+	return normalized, nil
+}
+
+// inFolder reports whether any of the tags is the folder or one of its subfolders
+func inFolder(tags []string, folder string) bool {
+	for _, tag := range tags {
+		if tag == folder || strings.HasPrefix(tag, folder+"/") {
+			return true
+		}
+	}
+	return false
+}
\ No newline at end of file
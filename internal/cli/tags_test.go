@@ -0,0 +1,46 @@
+package cli
+
+import "testing"
+
+func TestTagsAndFolders(t *testing.T) {
+	s, server := newTestState(t)
+	registerUser(t, s, "alice")
+	alphaURL, betaURL := server.URL+"/alpha.xml", server.URL+"/beta.xml"
+	mustRun(t, s, "addfeed", alphaURL)
+	mustRun(t, s, "addfeed", betaURL)
+	aggregate(t, s)
+
+	out := mustRun(t, s, "folders")
+	assertContains(t, out, "You have no folders yet.")
+
+	// Folder paths are cleaned up as they are added
+	mustRun(t, s, "tag", alphaURL, " Tech / Databases ", "reading")
+	mustRun(t, s, "tag", betaURL, "Tech")
+	out = mustRun(t, s, "tag", alphaURL)
+	assertContains(t, out, "Tech/Databases", "reading")
+	out = mustRun(t, s, "folders")
+	assertContains(t, out, "You have 3 folder(s)", "Tech (1 feed(s))", "Tech/Databases (1 feed(s))", "reading (1 feed(s))")
+
+	// A folder holds the feeds of its subfolders
+	out = mustRun(t, s, "browse", "--folder", "Tech")
+	assertContains(t, out, "Postgres performance", "Weekly roundup")
+	out = mustRun(t, s, "browse", "--folder", "Tech/Databases")
+	assertContains(t, out, "Postgres performance")
+	assertNotContains(t, out, "Weekly roundup")
+
+	// Only whole folder names match
+	out = mustRun(t, s, "browse", "--folder", "Te")
+	assertNotContains(t, out, "Postgres performance", "Weekly roundup")
+
+	out = mustRun(t, s, "tag", alphaURL, "Tech/Databases", "missing", "--remove")
+	assertContains(t, out, "Removed tag Tech/Databases", "is not tagged missing")
+	out = mustRun(t, s, "browse", "--folder", "Tech")
+	assertNotContains(t, out, "Postgres performance")
+
+	if _, err := run(t, s, "tag", alphaURL, "/"); err == nil {
+		t.Error("tagged a feed with an empty folder path")
+	}
+	if _, err := run(t, s, "tag", server.URL+"/missing.xml", "Tech"); err == nil {
+		t.Error("tagged a feed that doesn't exist")
+	}
+}
@@ -0,0 +1,105 @@
+package cli
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/PassZ/rss-aggregator/internal/database"
+)
+
+// timeFormat is the layout used to print timestamps
+const timeFormat = "2006-01-02 15:04:05 MST"
+
+// HandlerTimezone handles the timezone command
+func HandlerTimezone(s *State, cmd Command, user database.User) error {
+	// Without an argument, show the current setting
+	if len(cmd.Args) == 0 {
+		if user.Timezone.Valid {
+			fmt.Printf("Your timezone is %s\n", user.Timezone.String)
+		} else {
+			fmt.Printf("No timezone set, using the system timezone (%s)\n", time.Local)
+		}
+		return nil
+	}
+
+	// "local" clears the preference and goes back to the system timezone
+	var timezone sql.NullString
+	if cmd.Args[0] != "local" {
+		loc, err := time.LoadLocation(cmd.Args[0])
+		if err != nil {
+			return fmt.Errorf("unknown timezone '%s', use a name like Europe/Paris or UTC", cmd.Args[0])
+		}
+		timezone = sql.NullString{String: loc.String(), Valid: true}
+	}
+
+	updated, err := s.DB.SetUserTimezone(context.Background(), database.SetUserTimezoneParams{
+		ID:       user.ID,
+		Timezone: timezone,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set timezone: %w", err)
+	}
+
+	now := time.Now().In(userLocation(updated))
+	fmt.Printf("Timezone set to %s, it is now %s\n", now.Location(), now.Format(timeFormat))
+	return nil
+}
+
+// userLocation returns the user's display timezone, falling back to the system timezone
+func userLocation(user database.User) *time.Location {
+	if !user.Timezone.Valid {
+		return time.Local
+	}
+	loc, err := time.LoadLocation(user.Timezone.String)
+	if err != nil {
+		return time.Local
+	}
+	return loc
+}
+
+// currentLocation returns the display timezone of the logged in user, for commands that
+// don't require one
+func currentLocation(s *State) *time.Location {
+	user, err := currentUser(s)
+	if err != nil {
+		return time.Local
+	}
+	return userLocation(user)
+}
+
+// formatTime prints a timestamp in the given timezone followed by how long ago it was
+func formatTime(t time.Time, loc *time.Location) string {
+	return fmt.Sprintf("%s (%s)", t.In(loc).Format(timeFormat), relativeTime(t, time.Now()))
+}
+
+// relativeTime describes the distance between two times, like "3h ago" or "in 2d"
+func relativeTime(t, now time.Time) string {
+	d := now.Sub(t)
+	future := d < 0
+	if future {
+		d = -d
+	}
+
+	var amount string
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		amount = fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 24*time.Hour:
+		amount = fmt.Sprintf("%dh", int(d.Hours()))
+	case d < 30*24*time.Hour:
+		amount = fmt.Sprintf("%dd", int(d.Hours()/24))
+	case d < 365*24*time.Hour:
+		amount = fmt.Sprintf("%dmo", int(d.Hours()/24/30))
+	default:
+		amount = fmt.Sprintf("%dy", int(d.Hours()/24/365))
+	}
+
+	if future {
+		return "in " + amount
+	}
+	return amount + " ago"
+}
\ No newline at end of file
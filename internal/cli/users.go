@@ -0,0 +1,303 @@
+package cli
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/PassZ/rss-aggregator/internal/database"
+)
+
+// User roles, from most to least privileged
+const (
+	roleAdmin    = "admin"
+	roleMember   = "member"
+	roleReadOnly = "readonly"
+)
+
+// roles lists the roles in promotion order
+var roles = []string{roleReadOnly, roleMember, roleAdmin}
+
+// HandlerPromote handles the promote command
+func HandlerPromote(s *State, cmd Command, user database.User) error {
+	return changeRole(s, cmd, 1)
+}
+
+// HandlerDemote handles the demote command
+func HandlerDemote(s *State, cmd Command, user database.User) error {
+	return changeRole(s, cmd, -1)
+}
+
+// changeRole moves a user up or down one role, or straight to the role given with --role
+func changeRole(s *State, cmd Command, step int) error {
+	fs := newFlagSet(cmd)
+	role := fs.String("role", "", "role to give the user: admin, member or readonly")
+	args, err := parseFlags(fs, cmd.Args)
+	if err != nil {
+		return err
+	}
+
+	// Check if the command has the required argument
+	if len(args) == 0 {
+		return fmt.Errorf("usage: %s <username> [--role admin|member|readonly]", cmd.Name)
+	}
+
+	target, err := s.DB.GetUser(context.Background(), args[0])
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("user '%s' does not exist", args[0])
+		}
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	// Work out the new role
+	current := roleIndex(target.Role)
+	next := current + step
+	if *role != "" {
+		next = roleIndex(*role)
+		if next < 0 {
+			return fmt.Errorf("invalid role '%s', use admin, member or readonly", *role)
+		}
+		if (step > 0 && next < current) || (step < 0 && next > current) {
+			return fmt.Errorf("cannot %s %s from %s to %s", cmd.Name, target.Name, target.Role, *role)
+		}
+	}
+	if next < 0 || next >= len(roles) || next == current {
+		return fmt.Errorf("%s is already %s", target.Name, target.Role)
+	}
+
+	// Never leave the instance without an admin
+	if target.Role == roleAdmin {
+		admins, err := s.DB.CountAdmins(context.Background())
+		if err != nil {
+			return fmt.Errorf("failed to count admins: %w", err)
+		}
+		if admins <= 1 {
+			return fmt.Errorf("%s is the only admin, promote someone else first", target.Name)
+		}
+	}
+
+	updated, err := s.DB.SetUserRole(context.Background(), database.SetUserRoleParams{
+		ID:   target.ID,
+		Role: roles[next],
+	})
+	if err != nil {
+		return fmt.Errorf("failed to change role: %w", err)
+	}
+
+	fmt.Printf("%s is now %s\n", updated.Name, updated.Role)
+	return nil
+}
+
+// HandlerDeleteUser handles the deleteuser command
+func HandlerDeleteUser(s *State, cmd Command, user database.User) error {
+	fs := newFlagSet(cmd)
+	transferTo := fs.String("transfer-to", "", "give the user's feeds to another user")
+	deleteFeeds := fs.Bool("delete-feeds", false, "delete the user's feeds along with their posts")
+	yes := fs.Bool("yes", false, "delete without asking for confirmation")
+	args, err := parseFlags(fs, cmd.Args)
+	if err != nil {
+		return err
+	}
+
+	// Check if the command has the required argument
+	if len(args) == 0 {
+		return fmt.Errorf("usage: deleteuser <username> [--transfer-to user | --delete-feeds] [--yes]")
+	}
+	if *transferTo != "" && *deleteFeeds {
+		return fmt.Errorf("--transfer-to and --delete-feeds cannot be combined")
+	}
+
+	target, err := getManagedUser(s, user, args[0])
+	if err != nil {
+		return err
+	}
+	if target.Role == roleAdmin {
+		admins, err := s.DB.CountAdmins(context.Background())
+		if err != nil {
+			return fmt.Errorf("failed to count admins: %w", err)
+		}
+		if admins <= 1 {
+			return fmt.Errorf("%s is the only admin, promote someone else first", target.Name)
+		}
+	}
+
+	// Feeds are deleted with their owner, so make the user choose what happens to them
+	stats, err := s.DB.GetUserStats(context.Background(), target.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get user stats: %w", err)
+	}
+	if stats.FeedCount > 0 && *transferTo == "" && !*deleteFeeds {
+		return fmt.Errorf("%s added %d feed(s), use --transfer-to <user> to keep them or --delete-feeds to delete them",
+			target.Name, stats.FeedCount)
+	}
+
+	var newOwner database.User
+	if *transferTo != "" {
+		newOwner, err = s.DB.GetUser(context.Background(), *transferTo)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return fmt.Errorf("user '%s' does not exist", *transferTo)
+			}
+			return fmt.Errorf("failed to get user: %w", err)
+		}
+		if newOwner.ID == target.ID {
+			return fmt.Errorf("cannot transfer feeds to the user being deleted")
+		}
+	}
+
+	if !*yes {
+		question := fmt.Sprintf("Delete user '%s' with %d follow(s) and %d star(s)?", target.Name, stats.FollowCount, stats.StarCount)
+		if stats.FeedCount > 0 && *deleteFeeds {
+			question = fmt.Sprintf("Delete user '%s' with %d follow(s), %d star(s) and %d feed(s) including their posts?",
+				target.Name, stats.FollowCount, stats.StarCount, stats.FeedCount)
+		}
+		ok, err := confirm(question)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			fmt.Println("Aborted, nothing was deleted.")
+			return nil
+		}
+	}
+
+	if err := autoBackup(s, "deleteuser"); err != nil {
+		return fmt.Errorf("backup failed, nothing was deleted: %w", err)
+	}
+
+	// Transfer and delete together, so the feeds are never deleted with a user who was
+	// meant to hand them over
+	tx, err := s.DB.BeginTx(context.Background(), nil)
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var moved int64
+	if *transferTo != "" {
+		moved, err = tx.TransferUserFeeds(context.Background(), database.TransferUserFeedsParams{
+			ToUserID:   newOwner.ID,
+			FromUserID: target.ID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to transfer feeds: %w", err)
+		}
+	}
+
+	// Follows, stars, rules, sessions and any remaining feeds go through ON DELETE CASCADE
+	err = tx.DeleteUser(context.Background(), target.ID)
+	if err != nil {
+		return fmt.Errorf("failed to delete user: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit user deletion: %w", err)
+	}
+	if *transferTo != "" {
+		fmt.Printf("Transferred %d feed(s) to %s\n", moved, newOwner.Name)
+	}
+
+	// Deleting yourself also ends your session
+	if target.ID == user.ID {
+		if err := s.Config.SetSession(""); err != nil {
+			return fmt.Errorf("failed to clear session: %w", err)
+		}
+	}
+
+	fmt.Printf("Deleted user %s\n", target.Name)
+	return nil
+}
+
+// HandlerRenameUser handles the renameuser command
+func HandlerRenameUser(s *State, cmd Command, user database.User) error {
+	// Check if the command has the required arguments
+	if len(cmd.Args) < 2 {
+		return fmt.Errorf("usage: renameuser <username> <new-username>")
+	}
+
+	target, err := getManagedUser(s, user, cmd.Args[0])
+	if err != nil {
+		return err
+	}
+
+	// Check the new name is valid and free
+	newName, err := normalizeUsername(cmd.Args[1])
+	if err != nil {
+		return err
+	}
+	_, err = s.DB.GetUser(context.Background(), newName)
+	if err == nil {
+		return fmt.Errorf("user '%s' already exists", newName)
+	}
+	if err != sql.ErrNoRows {
+		return fmt.Errorf("failed to check user: %w", err)
+	}
+
+	renamed, err := s.DB.RenameUser(context.Background(), database.RenameUserParams{
+		ID:   target.ID,
+		Name: newName,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to rename user: %w", err)
+	}
+
+	fmt.Printf("Renamed %s to %s\n", target.Name, renamed.Name)
+	return nil
+}
+
+// HandlerWhoami handles the whoami command
+func HandlerWhoami(s *State, cmd Command, user database.User) error {
+	stats, err := s.DB.GetUserStats(context.Background(), user.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get user stats: %w", err)
+	}
+
+	loc := userLocation(user)
+	fmt.Printf("Logged in as %s\n", user.Name)
+	fmt.Printf("  Role: %s\n", user.Role)
+	fmt.Printf("  Member since: %s\n", formatTime(user.CreatedAt, loc))
+	fmt.Printf("  Timezone: %s\n", loc)
+	fmt.Printf("  Password: %t\n", user.PasswordHash.Valid)
+	fmt.Printf("  Feeds added: %d\n", stats.FeedCount)
+	fmt.Printf("  Following: %d feed(s)\n", stats.FollowCount)
+	fmt.Printf("  Posts: %d (%d unread)\n", stats.PostCount, stats.UnreadCount)
+	fmt.Printf("  Starred: %d\n", stats.StarCount)
+	return nil
+}
+
+// normalizeUsername trims a new username and rejects blank ones
+func normalizeUsername(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", fmt.Errorf("username cannot be blank")
+	}
+	return name, nil
+}
+
+// getManagedUser looks up a user by name and checks that the current user may manage them,
+// which admins may do for anyone and other users only for themselves
+func getManagedUser(s *State, current database.User, name string) (database.User, error) {
+	if name != current.Name && current.Role != roleAdmin {
+		return database.User{}, fmt.Errorf("only admins can manage other users")
+	}
+
+	user, err := s.DB.GetUser(context.Background(), name)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return database.User{}, fmt.Errorf("user '%s' does not exist", name)
+		}
+		return database.User{}, fmt.Errorf("failed to get user: %w", err)
+	}
+	return user, nil
+}
+
+// roleIndex returns the position of a role in promotion order, or -1 if it is unknown
+func roleIndex(role string) int {
+	for i, r := range roles {
+		if r == role {
+			return i
+		}
+	}
+	return -1
+}
\ No newline at end of file
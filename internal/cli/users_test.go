@@ -0,0 +1,161 @@
+package cli
+
+import (
+	"context"
+	"testing"
+)
+
+func TestPromoteDemote(t *testing.T) {
+	s, server := newTestState(t)
+	setInput(t, "")
+	mustRun(t, s, "register", "alice")
+	registerUser(t, s, "bob")
+
+	// Only admins change roles
+	if _, err := run(t, s, "promote", "bob"); err == nil {
+		t.Error("a member promoted themselves")
+	}
+
+	setInput(t, "")
+	mustRun(t, s, "login", "alice")
+	if _, err := run(t, s, "demote", "alice"); err == nil {
+		t.Error("the only admin was demoted")
+	}
+	if _, err := run(t, s, "promote", "carol"); err == nil {
+		t.Error("promoted a user who doesn't exist")
+	}
+
+	mustRun(t, s, "demote", "bob")
+	if bob := getUser(t, s, "bob"); bob.Role != roleReadOnly {
+		t.Fatalf("bob is %s after demoting a member, want %s", bob.Role, roleReadOnly)
+	}
+	if _, err := run(t, s, "demote", "bob"); err == nil {
+		t.Error("demoted a read-only user")
+	}
+	if _, err := run(t, s, "demote", "bob", "--role", "admin"); err == nil {
+		t.Error("demote raised a role")
+	}
+
+	// Read-only users can't add feeds
+	setInput(t, "")
+	mustRun(t, s, "login", "bob")
+	if _, err := run(t, s, "addfeed", server.URL+"/alpha.xml"); err == nil {
+		t.Error("a read-only user added a feed")
+	}
+
+	setInput(t, "")
+	mustRun(t, s, "login", "alice")
+	out := mustRun(t, s, "promote", "bob", "--role", "admin")
+	assertContains(t, out, "bob is now admin")
+
+	// With another admin around, alice may step down
+	mustRun(t, s, "demote", "alice")
+	if alice := getUser(t, s, "alice"); alice.Role != roleMember {
+		t.Errorf("alice is %s after demoting an admin, want %s", alice.Role, roleMember)
+	}
+}
+
+func TestDeleteUser(t *testing.T) {
+	s, server := newTestState(t)
+	setInput(t, "")
+	mustRun(t, s, "register", "alice")
+	bob := registerUser(t, s, "bob")
+	mustRun(t, s, "addfeed", server.URL+"/alpha.xml")
+	registerUser(t, s, "carol")
+
+	// Members only delete themselves
+	if _, err := run(t, s, "deleteuser", "bob", "--yes"); err == nil {
+		t.Error("a member deleted another user")
+	}
+
+	setInput(t, "")
+	mustRun(t, s, "login", "alice")
+	if _, err := run(t, s, "deleteuser", "alice", "--yes"); err == nil {
+		t.Error("the only admin was deleted")
+	}
+
+	// Feeds have to go somewhere
+	if _, err := run(t, s, "deleteuser", "bob", "--yes"); err == nil {
+		t.Error("deleted a user with feeds without saying what happens to them")
+	}
+	if _, err := run(t, s, "deleteuser", "bob", "--transfer-to", "bob", "--yes"); err == nil {
+		t.Error("transferred feeds to the user being deleted")
+	}
+
+	setInput(t, "n")
+	mustRun(t, s, "deleteuser", "bob", "--transfer-to", "alice")
+	getUser(t, s, "bob")
+
+	out := mustRun(t, s, "deleteuser", "bob", "--transfer-to", "alice", "--yes")
+	assertContains(t, out, "Transferred 1 feed(s) to alice", "Deleted user bob")
+	if _, err := s.DB.GetUser(context.Background(), "bob"); err == nil {
+		t.Error("bob still exists after being deleted")
+	}
+	if feed := getFeed(t, s, server.URL+"/alpha.xml"); feed.UserID == bob.ID {
+		t.Error("the feed still belongs to the deleted user")
+	}
+	if len(backups(t)) != 1 {
+		t.Errorf("got %d backup(s) after deleting a user, want 1", len(backups(t)))
+	}
+
+	// Deleting yourself logs you out
+	setInput(t, "")
+	mustRun(t, s, "login", "carol")
+	mustRun(t, s, "deleteuser", "carol", "--yes")
+	if s.Config.SessionToken != "" {
+		t.Error("still logged in after deleting yourself")
+	}
+}
+
+func TestRenameUser(t *testing.T) {
+	s, _ := newTestState(t)
+	setInput(t, "")
+	mustRun(t, s, "register", "alice")
+	registerUser(t, s, "bob")
+
+	if _, err := run(t, s, "renameuser", "alice", "mallory"); err == nil {
+		t.Error("a member renamed another user")
+	}
+	if _, err := run(t, s, "renameuser", "bob", "alice"); err == nil {
+		t.Error("renamed a user to a name that is taken")
+	}
+	if _, err := run(t, s, "renameuser", "bob", "  "); err == nil {
+		t.Error("renamed a user to a blank name")
+	}
+
+	out := mustRun(t, s, "renameuser", "bob", " robert ")
+	assertContains(t, out, "Renamed bob to robert")
+
+	// The session carries on, and the new name logs in
+	if user := loggedInUser(t, s); user.Name != "robert" {
+		t.Errorf("logged in as %s after renaming, want robert", user.Name)
+	}
+	setInput(t, "")
+	mustRun(t, s, "login", "robert")
+}
+
+func TestTimezone(t *testing.T) {
+	s, _ := newTestState(t)
+	registerUser(t, s, "alice")
+
+	out := mustRun(t, s, "timezone")
+	assertContains(t, out, "No timezone set")
+
+	mustRun(t, s, "timezone", "Europe/Paris")
+	if user := loggedInUser(t, s); user.Timezone.String != "Europe/Paris" {
+		t.Errorf("got timezone %q, want Europe/Paris", user.Timezone.String)
+	}
+	out = mustRun(t, s, "timezone")
+	assertContains(t, out, "Your timezone is Europe/Paris")
+	out = mustRun(t, s, "whoami")
+	assertContains(t, out, "Timezone: Europe/Paris")
+
+	if _, err := run(t, s, "timezone", "Mars/Olympus_Mons"); err == nil {
+		t.Error("set an unknown timezone")
+	}
+
+	mustRun(t, s, "timezone", "local")
+	if user := loggedInUser(t, s); user.Timezone.Valid {
+		t.Errorf("got timezone %q after going back to local, want none", user.Timezone.String)
+	}
+}
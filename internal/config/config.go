@@ -2,69 +2,372 @@ package config
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io/fs"
+	"net/url"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
 )
 
-const configFileName = ".gatorconfig.json"
+// legacyFileName is the config file in the home directory used before XDG paths
+const legacyFileName = ".gatorconfig.json"
 
-// Config represents the JSON file structure
+// DefaultProfile names the settings at the top level of the config file
+const DefaultProfile = "default"
+
+// Environment variables that override the config file for a single run
+const (
+	EnvConfig              = "GATOR_CONFIG"
+	EnvProfile             = "GATOR_PROFILE"
+	EnvDbURL               = "GATOR_DB_URL"
+	EnvRetentionMaxAgeDays = "GATOR_RETENTION_MAX_AGE_DAYS"
+	EnvRetentionMaxPosts   = "GATOR_RETENTION_MAX_POSTS"
+)
+
+// Profile holds the settings for one database
+type Profile struct {
+	DbURL string `json:"db_url,omitempty"`
+	// CurrentUserName is the user of configs written before sessions existed. It no longer
+	// logs anyone in and is only kept to tell that user to log in again, until they do.
+	CurrentUserName string `json:"current_user_name,omitempty"`
+	// SessionToken identifies the logged in user's session
+	SessionToken string `json:"session_token,omitempty"`
+	// RetentionMaxAgeDays and RetentionMaxPosts are the default retention policy for
+	// feeds without their own, 0 keeps posts forever
+	RetentionMaxAgeDays int `json:"retention_max_age_days,omitempty"`
+	RetentionMaxPosts   int `json:"retention_max_posts,omitempty"`
+}
+
+// Config holds the settings of the active profile. Environment variables override
+// them for the current run only, they are never written back to the file.
 type Config struct {
-	DbURL           string `json:"db_url"`
-	CurrentUserName string `json:"current_user_name"`
+	Profile
+	// ProfileName is the name of the active profile
+	ProfileName string
+	// Path is the config file the settings come from, which may not exist yet
+	Path string
+	file file
 }
 
-// Read reads the JSON file found at ~/.gatorconfig.json and returns a Config struct
-func Read() (*Config, error) {
-	configPath, err := getConfigFilePath()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get config file path: %w", err)
+// file is the JSON structure of the config file. Its top-level settings are the
+// default profile, so files written before profiles existed read as they are.
+type file struct {
+	Profile
+	CurrentProfile string             `json:"current_profile,omitempty"`
+	Profiles       map[string]Profile `json:"profiles,omitempty"`
+}
+
+// Read reads the config file and selects the active profile. An empty path or profile
+// falls back to GATOR_CONFIG and GATOR_PROFILE, then to the default file and the
+// profile chosen with 'gator profile use'. A missing file reads as an empty config.
+func Read(path, profile string) (*Config, error) {
+	if path == "" {
+		var err error
+		path, err = FilePath()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get config file path: %w", err)
+		}
 	}
+	cfg := &Config{Path: path}
 
-	data, err := os.ReadFile(configPath)
-	if err != nil {
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
 		return nil, fmt.Errorf("failed to read config file: %w", err)
 	}
+	if err == nil {
+		if err := json.Unmarshal(data, &cfg.file); err != nil {
+			return nil, fmt.Errorf("config file %s is not valid JSON: %w", path, err)
+		}
+	}
 
-	var cfg Config
-	if err := json.Unmarshal(data, &cfg); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
+	if profile == "" {
+		profile = os.Getenv(EnvProfile)
+	}
+	if profile == "" {
+		profile = cfg.file.CurrentProfile
+	}
+	if profile == "" {
+		profile = DefaultProfile
 	}
+	settings, ok := cfg.file.profile(profile)
+	if !ok {
+		return nil, fmt.Errorf("profile '%s' does not exist in %s, use one of: %s",
+			profile, path, strings.Join(cfg.ProfileNames(), ", "))
+	}
+	cfg.ProfileName = profile
+	cfg.Profile = settings
 
-	return &cfg, nil
+	if err := cfg.applyEnv(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
 }
 
-// SetUser sets the current_user_name field and writes the config to the JSON file
-func (c *Config) SetUser(username string) error {
-	c.CurrentUserName = username
-	return write(*c)
-}
+// FilePath returns the config file to use: GATOR_CONFIG when set, otherwise
+// gator/config.json in the XDG config directory, unless only a ~/.gatorconfig.json
+// written by an older version exists
+func FilePath() (string, error) {
+	if path := os.Getenv(EnvConfig); path != "" {
+		return path, nil
+	}
 
-// getConfigFilePath returns the full path to the config file in the home directory
-func getConfigFilePath() (string, error) {
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
 		return "", err
 	}
-	return filepath.Join(homeDir, configFileName), nil
+
+	// Relative XDG paths are invalid and must be ignored
+	configDir := os.Getenv("XDG_CONFIG_HOME")
+	if !filepath.IsAbs(configDir) {
+		configDir = filepath.Join(homeDir, ".config")
+	}
+	path := filepath.Join(configDir, "gator", "config.json")
+	if _, err := os.Stat(path); err == nil {
+		return path, nil
+	}
+
+	legacyPath := filepath.Join(homeDir, legacyFileName)
+	if _, err := os.Stat(legacyPath); err == nil {
+		return legacyPath, nil
+	}
+	return path, nil
+}
+
+// applyEnv overrides settings with the environment variables that are set
+func (c *Config) applyEnv() error {
+	if dbURL := os.Getenv(EnvDbURL); dbURL != "" {
+		c.DbURL = dbURL
+	}
+	for name, value := range map[string]*int{
+		EnvRetentionMaxAgeDays: &c.RetentionMaxAgeDays,
+		EnvRetentionMaxPosts:   &c.RetentionMaxPosts,
+	} {
+		raw := os.Getenv(name)
+		if raw == "" {
+			continue
+		}
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			return fmt.Errorf("%s must be a whole number, got '%s'", name, raw)
+		}
+		*value = n
+	}
+	return nil
+}
+
+// Validate checks that the active profile has what commands need to use the database
+func (c *Config) Validate() error {
+	if c.DbURL == "" {
+		if _, err := os.Stat(c.Path); errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("no config file at %s, run 'gator init' or set %s", c.Path, EnvDbURL)
+		}
+		return fmt.Errorf("profile '%s' in %s has no db_url, run 'gator init' or set %s", c.ProfileName, c.Path, EnvDbURL)
+	}
+
+	if err := CheckDbURL(c.DbURL); err != nil {
+		if os.Getenv(EnvDbURL) != "" {
+			return fmt.Errorf("invalid %s: %w", EnvDbURL, err)
+		}
+		return fmt.Errorf("invalid db_url for profile '%s' in %s: %w", c.ProfileName, c.Path, err)
+	}
+
+	if c.RetentionMaxAgeDays < 0 {
+		return fmt.Errorf("retention_max_age_days of profile '%s' cannot be negative, use 0 to keep posts forever", c.ProfileName)
+	}
+	if c.RetentionMaxPosts < 0 {
+		return fmt.Errorf("retention_max_posts of profile '%s' cannot be negative, use 0 to keep posts forever", c.ProfileName)
+	}
+	return nil
+}
+
+// SetSession stores the session token of the logged in user and writes the config to the JSON file.
+// An empty token logs the user out.
+func (c *Config) SetSession(token string) error {
+	c.SessionToken = token
+	c.CurrentUserName = ""
+
+	// Only the session is saved, not settings that came from the environment
+	settings, _ := c.file.profile(c.ProfileName)
+	settings.SessionToken = token
+	settings.CurrentUserName = ""
+	c.file.setProfile(c.ProfileName, settings)
+	return write(c.Path, c.file)
+}
+
+// SetDbURL saves the database of the active profile and writes the config to the JSON file.
+// A session only exists in the database it was started in, so switching to another
+// database logs the user out.
+func (c *Config) SetDbURL(dbURL string) error {
+	if err := CheckDbURL(dbURL); err != nil {
+		return fmt.Errorf("invalid db_url: %w", err)
+	}
+
+	settings, _ := c.file.profile(c.ProfileName)
+	if settings.DbURL != dbURL {
+		settings.SessionToken = ""
+		settings.CurrentUserName = ""
+	}
+	settings.DbURL = dbURL
+	c.file.setProfile(c.ProfileName, settings)
+
+	c.DbURL = dbURL
+	c.SessionToken = settings.SessionToken
+	c.CurrentUserName = settings.CurrentUserName
+	return write(c.Path, c.file)
+}
+
+// ProfileNames returns the names of all profiles, the default one first
+func (c *Config) ProfileNames() []string {
+	names := make([]string, 0, len(c.file.Profiles)+1)
+	for name := range c.file.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return append([]string{DefaultProfile}, names...)
 }
 
-// write writes the config struct to the JSON file
-func write(cfg Config) error {
-	configPath, err := getConfigFilePath()
+// GetProfile returns the saved settings of a profile, without environment overrides
+func (c *Config) GetProfile(name string) (Profile, bool) {
+	return c.file.profile(name)
+}
+
+// UseProfile makes a profile the active one for later runs
+func (c *Config) UseProfile(name string) error {
+	if _, ok := c.file.profile(name); !ok {
+		return fmt.Errorf("profile '%s' does not exist, use one of: %s", name, strings.Join(c.ProfileNames(), ", "))
+	}
+
+	c.file.CurrentProfile = name
+	if name == DefaultProfile {
+		c.file.CurrentProfile = ""
+	}
+	return write(c.Path, c.file)
+}
+
+// AddProfile saves a new profile for the database at dbURL
+func (c *Config) AddProfile(name, dbURL string) error {
+	if name == "" || strings.ContainsFunc(name, func(r rune) bool { return r == '/' || r == ' ' || r == '\t' }) {
+		return fmt.Errorf("invalid profile name '%s', use a single word like work or personal", name)
+	}
+	if _, ok := c.file.profile(name); ok {
+		return fmt.Errorf("profile '%s' already exists", name)
+	}
+	if err := CheckDbURL(dbURL); err != nil {
+		return fmt.Errorf("invalid db_url: %w", err)
+	}
+
+	if c.file.Profiles == nil {
+		c.file.Profiles = make(map[string]Profile)
+	}
+	c.file.Profiles[name] = Profile{DbURL: dbURL}
+	return write(c.Path, c.file)
+}
+
+// RemoveProfile deletes a profile along with its session
+func (c *Config) RemoveProfile(name string) error {
+	if name == DefaultProfile {
+		return fmt.Errorf("the default profile cannot be removed")
+	}
+	if _, ok := c.file.Profiles[name]; !ok {
+		return fmt.Errorf("profile '%s' does not exist", name)
+	}
+	if name == c.file.CurrentProfile {
+		return fmt.Errorf("profile '%s' is in use, switch to another one first", name)
+	}
+
+	delete(c.file.Profiles, name)
+	return write(c.Path, c.file)
+}
+
+// CheckDbURL checks that a db_url names a database gator can use
+func CheckDbURL(dbURL string) error {
+	scheme, rest, _ := strings.Cut(dbURL, "://")
+	switch strings.ToLower(scheme) {
+	case "postgres", "postgresql":
+	case "sqlite", "sqlite3":
+		if rest == "" {
+			return fmt.Errorf("sqlite:// needs a file path, like sqlite://~/.gator/gator.db")
+		}
+	default:
+		return fmt.Errorf("it must start with postgres://, postgresql:// or sqlite://")
+	}
+	return nil
+}
+
+// RedactedDbURL returns a db_url with its password hidden, for printing
+func RedactedDbURL(dbURL string) string {
+	parsed, err := url.Parse(dbURL)
 	if err != nil {
-		return fmt.Errorf("failed to get config file path: %w", err)
+		return dbURL
 	}
+	return parsed.Redacted()
+}
+
+// profile returns the settings of the named profile
+func (f *file) profile(name string) (Profile, bool) {
+	if name == DefaultProfile {
+		return f.Profile, true
+	}
+	settings, ok := f.Profiles[name]
+	return settings, ok
+}
+
+// setProfile replaces the settings of the named profile
+func (f *file) setProfile(name string, settings Profile) {
+	if name == DefaultProfile {
+		f.Profile = settings
+		return
+	}
+	f.Profiles[name] = settings
+}
 
+// write saves the config file atomically: it is written next to the old one and
+// renamed over it, so a crash never leaves a half-written config. It holds session
+// tokens and database passwords, so only the owner may read it.
+func write(path string, cfg file) error {
 	data, err := json.MarshalIndent(cfg, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal config: %w", err)
 	}
 
-	if err := os.WriteFile(configPath, data, 0644); err != nil {
+	// Replace the file a symlink points to rather than the symlink
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		path = resolved
+	}
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(dir, ".gator-config-*")
+	if err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	// Does nothing once the file has been renamed
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	if err := tmp.Chmod(0600); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
 		return fmt.Errorf("failed to write config file: %w", err)
 	}
 
 	return nil
-}
\ No newline at end of file
+}
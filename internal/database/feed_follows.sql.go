@@ -7,6 +7,7 @@ package database
 
 import (
 	"context"
+	"database/sql"
 	"time"
 
 	"github.com/google/uuid"
@@ -16,7 +17,7 @@ const createFeedFollow = `-- name: CreateFeedFollow :one
 WITH inserted_follow AS (
     INSERT INTO feed_follows (id, created_at, updated_at, user_id, feed_id)
     VALUES ($1, $2, $3, $4, $5)
-    RETURNING id, created_at, updated_at, user_id, feed_id
+    RETURNING id, created_at, updated_at, user_id, feed_id, custom_title, muted, priority, notify
 )
 SELECT 
     if.id,
@@ -85,6 +86,72 @@ func (q *Queries) DeleteFeedFollow(ctx context.Context, arg DeleteFeedFollowPara
 	return err
 }
 
+const getFeedFollow = `-- name: GetFeedFollow :one
+SELECT id, created_at, updated_at, user_id, feed_id, custom_title, muted, priority, notify FROM feed_follows
+WHERE user_id = $1 AND feed_id = $2
+`
+
+type GetFeedFollowParams struct {
+	UserID uuid.UUID
+	FeedID uuid.UUID
+}
+
+func (q *Queries) GetFeedFollow(ctx context.Context, arg GetFeedFollowParams) (FeedFollow, error) {
+	row := q.db.QueryRowContext(ctx, getFeedFollow, arg.UserID, arg.FeedID)
+	var i FeedFollow
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.UserID,
+		&i.FeedID,
+		&i.CustomTitle,
+		&i.Muted,
+		&i.Priority,
+		&i.Notify,
+	)
+	return i, err
+}
+
+const getFeedFollowersToNotify = `-- name: GetFeedFollowersToNotify :many
+SELECT
+    u.name as user_name,
+    COALESCE(ff.custom_title, f.name)::text as feed_name
+FROM feed_follows ff
+JOIN users u ON ff.user_id = u.id
+JOIN feeds f ON ff.feed_id = f.id
+WHERE ff.feed_id = $1 AND ff.notify AND NOT ff.muted
+ORDER BY u.name
+`
+
+type GetFeedFollowersToNotifyRow struct {
+	UserName string
+	FeedName string
+}
+
+func (q *Queries) GetFeedFollowersToNotify(ctx context.Context, feedID uuid.UUID) ([]GetFeedFollowersToNotifyRow, error) {
+	rows, err := q.db.QueryContext(ctx, getFeedFollowersToNotify, feedID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetFeedFollowersToNotifyRow
+	for rows.Next() {
+		var i GetFeedFollowersToNotifyRow
+		if err := rows.Scan(&i.UserName, &i.FeedName); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getFeedFollowsForUser = `-- name: GetFeedFollowsForUser :many
 SELECT 
     ff.id,
@@ -92,23 +159,48 @@ SELECT
     ff.updated_at,
     ff.user_id,
     ff.feed_id,
+    ff.custom_title,
+    ff.muted,
+    ff.priority,
+    ff.notify,
     u.name as user_name,
-    f.name as feed_name
+    COALESCE(ff.custom_title, f.name)::text as feed_name,
+    f.url as feed_url,
+    f.link as feed_link,
+    (
+        SELECT COUNT(*) FROM posts p
+        WHERE p.feed_id = ff.feed_id
+          AND NOT EXISTS (
+              SELECT 1 FROM post_reads pr
+              WHERE pr.post_id = p.id AND pr.user_id = ff.user_id
+          )
+          AND NOT EXISTS (
+              SELECT 1 FROM post_hides ph
+              WHERE ph.post_id = p.id AND ph.user_id = ff.user_id
+          )
+    ) as unread_count
 FROM feed_follows ff
 JOIN users u ON ff.user_id = u.id
 JOIN feeds f ON ff.feed_id = f.id
 WHERE ff.user_id = $1
-ORDER BY ff.created_at DESC
+ORDER BY ff.priority DESC, ff.created_at DESC
 `
 
 type GetFeedFollowsForUserRow struct {
-	ID        uuid.UUID
-	CreatedAt time.Time
-	UpdatedAt time.Time
-	UserID    uuid.UUID
-	FeedID    uuid.UUID
-	UserName  string
-	FeedName  string
+	ID          uuid.UUID
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	UserID      uuid.UUID
+	FeedID      uuid.UUID
+	CustomTitle sql.NullString
+	Muted       bool
+	Priority    int32
+	Notify      bool
+	UserName    string
+	FeedName    string
+	FeedUrl     string
+	FeedLink    sql.NullString
+	UnreadCount int64
 }
 
 func (q *Queries) GetFeedFollowsForUser(ctx context.Context, userID uuid.UUID) ([]GetFeedFollowsForUserRow, error) {
@@ -126,8 +218,15 @@ func (q *Queries) GetFeedFollowsForUser(ctx context.Context, userID uuid.UUID) (
 			&i.UpdatedAt,
 			&i.UserID,
 			&i.FeedID,
+			&i.CustomTitle,
+			&i.Muted,
+			&i.Priority,
+			&i.Notify,
 			&i.UserName,
 			&i.FeedName,
+			&i.FeedUrl,
+			&i.FeedLink,
+			&i.UnreadCount,
 		); err != nil {
 			return nil, err
 		}
@@ -141,3 +240,62 @@ func (q *Queries) GetFeedFollowsForUser(ctx context.Context, userID uuid.UUID) (
 	}
 	return items, nil
 }
+
+const moveFeedFollows = `-- name: MoveFeedFollows :execrows
+INSERT INTO feed_follows (id, created_at, updated_at, user_id, feed_id, custom_title, muted, priority, notify)
+SELECT gen_random_uuid(), ff.created_at, NOW(), ff.user_id, $1, ff.custom_title, ff.muted, ff.priority, ff.notify
+FROM feed_follows ff
+WHERE ff.feed_id = $2
+ON CONFLICT (user_id, feed_id) DO NOTHING
+`
+
+type MoveFeedFollowsParams struct {
+	ToFeedID   uuid.UUID
+	FromFeedID uuid.UUID
+}
+
+func (q *Queries) MoveFeedFollows(ctx context.Context, arg MoveFeedFollowsParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, moveFeedFollows, arg.ToFeedID, arg.FromFeedID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const updateFeedFollowSettings = `-- name: UpdateFeedFollowSettings :one
+UPDATE feed_follows
+SET custom_title = $2, muted = $3, priority = $4, notify = $5, updated_at = NOW()
+WHERE id = $1
+RETURNING id, created_at, updated_at, user_id, feed_id, custom_title, muted, priority, notify
+`
+
+type UpdateFeedFollowSettingsParams struct {
+	ID          uuid.UUID
+	CustomTitle sql.NullString
+	Muted       bool
+	Priority    int32
+	Notify      bool
+}
+
+func (q *Queries) UpdateFeedFollowSettings(ctx context.Context, arg UpdateFeedFollowSettingsParams) (FeedFollow, error) {
+	row := q.db.QueryRowContext(ctx, updateFeedFollowSettings,
+		arg.ID,
+		arg.CustomTitle,
+		arg.Muted,
+		arg.Priority,
+		arg.Notify,
+	)
+	var i FeedFollow
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.UserID,
+		&i.FeedID,
+		&i.CustomTitle,
+		&i.Muted,
+		&i.Priority,
+		&i.Notify,
+	)
+	return i, err
+}
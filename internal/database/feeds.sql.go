@@ -7,22 +7,24 @@ package database
 
 import (
 	"context"
+	"database/sql"
 	"time"
 
 	"github.com/google/uuid"
 )
 
 const createFeed = `-- name: CreateFeed :one
-INSERT INTO feeds (id, created_at, updated_at, name, url, user_id)
+INSERT INTO feeds (id, created_at, updated_at, name, url, user_id, url_key)
 VALUES (
     $1,
     $2,
     $3,
     $4,
     $5,
-    $6
+    $6,
+    $7
 )
-RETURNING id, created_at, updated_at, name, url, user_id, last_fetched_at
+RETURNING id, created_at, updated_at, name, url, user_id, last_fetched_at, title, description, link, language, image_url, paused, url_key, content_hash, retention_max_age_days, retention_max_posts
 `
 
 type CreateFeedParams struct {
@@ -32,6 +34,7 @@ type CreateFeedParams struct {
 	Name      string
 	Url       string
 	UserID    uuid.UUID
+	UrlKey    sql.NullString
 }
 
 func (q *Queries) CreateFeed(ctx context.Context, arg CreateFeedParams) (Feed, error) {
@@ -42,6 +45,7 @@ func (q *Queries) CreateFeed(ctx context.Context, arg CreateFeedParams) (Feed, e
 		arg.Name,
 		arg.Url,
 		arg.UserID,
+		arg.UrlKey,
 	)
 	var i Feed
 	err := row.Scan(
@@ -52,12 +56,32 @@ func (q *Queries) CreateFeed(ctx context.Context, arg CreateFeedParams) (Feed, e
 		&i.Url,
 		&i.UserID,
 		&i.LastFetchedAt,
+		&i.Title,
+		&i.Description,
+		&i.Link,
+		&i.Language,
+		&i.ImageUrl,
+		&i.Paused,
+		&i.UrlKey,
+		&i.ContentHash,
+		&i.RetentionMaxAgeDays,
+		&i.RetentionMaxPosts,
 	)
 	return i, err
 }
 
+const deleteFeed = `-- name: DeleteFeed :exec
+DELETE FROM feeds
+WHERE id = $1
+`
+
+func (q *Queries) DeleteFeed(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, deleteFeed, id)
+	return err
+}
+
 const getFeedByURL = `-- name: GetFeedByURL :one
-SELECT id, created_at, updated_at, name, url, user_id, last_fetched_at FROM feeds
+SELECT id, created_at, updated_at, name, url, user_id, last_fetched_at, title, description, link, language, image_url, paused, url_key, content_hash, retention_max_age_days, retention_max_posts FROM feeds
 WHERE url = $1
 `
 
@@ -72,10 +96,68 @@ func (q *Queries) GetFeedByURL(ctx context.Context, url string) (Feed, error) {
 		&i.Url,
 		&i.UserID,
 		&i.LastFetchedAt,
+		&i.Title,
+		&i.Description,
+		&i.Link,
+		&i.Language,
+		&i.ImageUrl,
+		&i.Paused,
+		&i.UrlKey,
+		&i.ContentHash,
+		&i.RetentionMaxAgeDays,
+		&i.RetentionMaxPosts,
 	)
 	return i, err
 }
 
+const getFeedByURLKey = `-- name: GetFeedByURLKey :one
+SELECT id, created_at, updated_at, name, url, user_id, last_fetched_at, title, description, link, language, image_url, paused, url_key, content_hash, retention_max_age_days, retention_max_posts FROM feeds
+WHERE url_key = $1
+`
+
+func (q *Queries) GetFeedByURLKey(ctx context.Context, urlKey sql.NullString) (Feed, error) {
+	row := q.db.QueryRowContext(ctx, getFeedByURLKey, urlKey)
+	var i Feed
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Name,
+		&i.Url,
+		&i.UserID,
+		&i.LastFetchedAt,
+		&i.Title,
+		&i.Description,
+		&i.Link,
+		&i.Language,
+		&i.ImageUrl,
+		&i.Paused,
+		&i.UrlKey,
+		&i.ContentHash,
+		&i.RetentionMaxAgeDays,
+		&i.RetentionMaxPosts,
+	)
+	return i, err
+}
+
+const getFeedStats = `-- name: GetFeedStats :one
+SELECT
+    (SELECT COUNT(*) FROM feed_follows WHERE feed_follows.feed_id = $1) as follow_count,
+    (SELECT COUNT(*) FROM posts WHERE posts.feed_id = $1) as post_count
+`
+
+type GetFeedStatsRow struct {
+	FollowCount int64
+	PostCount   int64
+}
+
+func (q *Queries) GetFeedStats(ctx context.Context, feedID uuid.UUID) (GetFeedStatsRow, error) {
+	row := q.db.QueryRowContext(ctx, getFeedStats, feedID)
+	var i GetFeedStatsRow
+	err := row.Scan(&i.FollowCount, &i.PostCount)
+	return i, err
+}
+
 const getFeeds = `-- name: GetFeeds :many
 SELECT 
     f.id,
@@ -84,20 +166,41 @@ SELECT
     f.name,
     f.url,
     f.user_id,
-    u.name as user_name
+    f.last_fetched_at,
+    f.title,
+    f.description,
+    f.link,
+    f.language,
+    f.image_url,
+    f.paused,
+    f.url_key,
+    f.content_hash,
+    u.name as user_name,
+    fi.content_type as icon_content_type
 FROM feeds f
 JOIN users u ON f.user_id = u.id
+LEFT JOIN feed_icons fi ON f.id = fi.feed_id
 ORDER BY f.created_at DESC
 `
 
 type GetFeedsRow struct {
-	ID        uuid.UUID
-	CreatedAt time.Time
-	UpdatedAt time.Time
-	Name      string
-	Url       string
-	UserID    uuid.UUID
-	UserName  string
+	ID              uuid.UUID
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	Name            string
+	Url             string
+	UserID          uuid.UUID
+	LastFetchedAt   sql.NullTime
+	Title           sql.NullString
+	Description     sql.NullString
+	Link            sql.NullString
+	Language        sql.NullString
+	ImageUrl        sql.NullString
+	Paused          bool
+	UrlKey          sql.NullString
+	ContentHash     sql.NullString
+	UserName        string
+	IconContentType sql.NullString
 }
 
 func (q *Queries) GetFeeds(ctx context.Context) ([]GetFeedsRow, error) {
@@ -116,7 +219,17 @@ func (q *Queries) GetFeeds(ctx context.Context) ([]GetFeedsRow, error) {
 			&i.Name,
 			&i.Url,
 			&i.UserID,
+			&i.LastFetchedAt,
+			&i.Title,
+			&i.Description,
+			&i.Link,
+			&i.Language,
+			&i.ImageUrl,
+			&i.Paused,
+			&i.UrlKey,
+			&i.ContentHash,
 			&i.UserName,
+			&i.IconContentType,
 		); err != nil {
 			return nil, err
 		}
@@ -132,7 +245,8 @@ func (q *Queries) GetFeeds(ctx context.Context) ([]GetFeedsRow, error) {
 }
 
 const getNextFeedToFetch = `-- name: GetNextFeedToFetch :one
-SELECT id, created_at, updated_at, name, url, user_id, last_fetched_at FROM feeds
+SELECT id, created_at, updated_at, name, url, user_id, last_fetched_at, title, description, link, language, image_url, paused, url_key, content_hash, retention_max_age_days, retention_max_posts FROM feeds
+WHERE NOT paused
 ORDER BY last_fetched_at NULLS FIRST, created_at ASC
 LIMIT 1
 `
@@ -148,6 +262,16 @@ func (q *Queries) GetNextFeedToFetch(ctx context.Context) (Feed, error) {
 		&i.Url,
 		&i.UserID,
 		&i.LastFetchedAt,
+		&i.Title,
+		&i.Description,
+		&i.Link,
+		&i.Language,
+		&i.ImageUrl,
+		&i.Paused,
+		&i.UrlKey,
+		&i.ContentHash,
+		&i.RetentionMaxAgeDays,
+		&i.RetentionMaxPosts,
 	)
 	return i, err
 }
@@ -162,3 +286,181 @@ func (q *Queries) MarkFeedFetched(ctx context.Context, id uuid.UUID) error {
 	_, err := q.db.ExecContext(ctx, markFeedFetched, id)
 	return err
 }
+
+const setFeedContentHash = `-- name: SetFeedContentHash :exec
+UPDATE feeds
+SET content_hash = $2
+WHERE id = $1
+`
+
+type SetFeedContentHashParams struct {
+	ID          uuid.UUID
+	ContentHash sql.NullString
+}
+
+func (q *Queries) SetFeedContentHash(ctx context.Context, arg SetFeedContentHashParams) error {
+	_, err := q.db.ExecContext(ctx, setFeedContentHash, arg.ID, arg.ContentHash)
+	return err
+}
+
+const setFeedRetention = `-- name: SetFeedRetention :one
+UPDATE feeds
+SET retention_max_age_days = $2, retention_max_posts = $3, updated_at = NOW()
+WHERE id = $1
+RETURNING id, created_at, updated_at, name, url, user_id, last_fetched_at, title, description, link, language, image_url, paused, url_key, content_hash, retention_max_age_days, retention_max_posts
+`
+
+type SetFeedRetentionParams struct {
+	ID                  uuid.UUID
+	RetentionMaxAgeDays sql.NullInt32
+	RetentionMaxPosts   sql.NullInt32
+}
+
+func (q *Queries) SetFeedRetention(ctx context.Context, arg SetFeedRetentionParams) (Feed, error) {
+	row := q.db.QueryRowContext(ctx, setFeedRetention, arg.ID, arg.RetentionMaxAgeDays, arg.RetentionMaxPosts)
+	var i Feed
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Name,
+		&i.Url,
+		&i.UserID,
+		&i.LastFetchedAt,
+		&i.Title,
+		&i.Description,
+		&i.Link,
+		&i.Language,
+		&i.ImageUrl,
+		&i.Paused,
+		&i.UrlKey,
+		&i.ContentHash,
+		&i.RetentionMaxAgeDays,
+		&i.RetentionMaxPosts,
+	)
+	return i, err
+}
+
+const setFeedURLKey = `-- name: SetFeedURLKey :exec
+UPDATE feeds
+SET url_key = $2
+WHERE id = $1
+`
+
+type SetFeedURLKeyParams struct {
+	ID     uuid.UUID
+	UrlKey sql.NullString
+}
+
+func (q *Queries) SetFeedURLKey(ctx context.Context, arg SetFeedURLKeyParams) error {
+	_, err := q.db.ExecContext(ctx, setFeedURLKey, arg.ID, arg.UrlKey)
+	return err
+}
+
+const transferFeed = `-- name: TransferFeed :exec
+UPDATE feeds
+SET user_id = $2, updated_at = NOW()
+WHERE id = $1
+`
+
+type TransferFeedParams struct {
+	ID     uuid.UUID
+	UserID uuid.UUID
+}
+
+func (q *Queries) TransferFeed(ctx context.Context, arg TransferFeedParams) error {
+	_, err := q.db.ExecContext(ctx, transferFeed, arg.ID, arg.UserID)
+	return err
+}
+
+const transferUserFeeds = `-- name: TransferUserFeeds :execrows
+UPDATE feeds
+SET user_id = $1, updated_at = NOW()
+WHERE user_id = $2
+`
+
+type TransferUserFeedsParams struct {
+	ToUserID   uuid.UUID
+	FromUserID uuid.UUID
+}
+
+func (q *Queries) TransferUserFeeds(ctx context.Context, arg TransferUserFeedsParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, transferUserFeeds, arg.ToUserID, arg.FromUserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const updateFeed = `-- name: UpdateFeed :one
+UPDATE feeds
+SET name = $2, url = $3, url_key = $4, paused = $5, updated_at = NOW()
+WHERE id = $1
+RETURNING id, created_at, updated_at, name, url, user_id, last_fetched_at, title, description, link, language, image_url, paused, url_key, content_hash, retention_max_age_days, retention_max_posts
+`
+
+type UpdateFeedParams struct {
+	ID     uuid.UUID
+	Name   string
+	Url    string
+	UrlKey sql.NullString
+	Paused bool
+}
+
+func (q *Queries) UpdateFeed(ctx context.Context, arg UpdateFeedParams) (Feed, error) {
+	row := q.db.QueryRowContext(ctx, updateFeed,
+		arg.ID,
+		arg.Name,
+		arg.Url,
+		arg.UrlKey,
+		arg.Paused,
+	)
+	var i Feed
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Name,
+		&i.Url,
+		&i.UserID,
+		&i.LastFetchedAt,
+		&i.Title,
+		&i.Description,
+		&i.Link,
+		&i.Language,
+		&i.ImageUrl,
+		&i.Paused,
+		&i.UrlKey,
+		&i.ContentHash,
+		&i.RetentionMaxAgeDays,
+		&i.RetentionMaxPosts,
+	)
+	return i, err
+}
+
+const updateFeedMetadata = `-- name: UpdateFeedMetadata :exec
+UPDATE feeds
+SET title = $2, description = $3, link = $4, language = $5, image_url = $6, updated_at = NOW()
+WHERE id = $1
+`
+
+type UpdateFeedMetadataParams struct {
+	ID          uuid.UUID
+	Title       sql.NullString
+	Description sql.NullString
+	Link        sql.NullString
+	Language    sql.NullString
+	ImageUrl    sql.NullString
+}
+
+func (q *Queries) UpdateFeedMetadata(ctx context.Context, arg UpdateFeedMetadataParams) error {
+	_, err := q.db.ExecContext(ctx, updateFeedMetadata,
+		arg.ID,
+		arg.Title,
+		arg.Description,
+		arg.Link,
+		arg.Language,
+		arg.ImageUrl,
+	)
+	return err
+}
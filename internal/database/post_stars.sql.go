@@ -0,0 +1,130 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: post_stars.sql
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const getStarredPosts = `-- name: GetStarredPosts :many
+SELECT id, created_at, updated_at, user_id, post_id, post_url, title, description, published_at, feed_name, note FROM post_stars
+WHERE user_id = $1
+ORDER BY created_at DESC
+`
+
+func (q *Queries) GetStarredPosts(ctx context.Context, userID uuid.UUID) ([]PostStar, error) {
+	rows, err := q.db.QueryContext(ctx, getStarredPosts, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []PostStar
+	for rows.Next() {
+		var i PostStar
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.UserID,
+			&i.PostID,
+			&i.PostUrl,
+			&i.Title,
+			&i.Description,
+			&i.PublishedAt,
+			&i.FeedName,
+			&i.Note,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const starPost = `-- name: StarPost :one
+INSERT INTO post_stars (id, created_at, updated_at, user_id, post_id, post_url, title, description, published_at, feed_name, note)
+SELECT
+    $1,
+    $2,
+    $2,
+    $3,
+    p.id,
+    p.url,
+    p.title,
+    p.description,
+    p.published_at,
+    f.name,
+    $4
+FROM posts p
+JOIN feeds f ON p.feed_id = f.id
+WHERE p.id = $5
+ON CONFLICT (user_id, post_url) DO UPDATE
+SET note = COALESCE(EXCLUDED.note, post_stars.note),
+    post_id = EXCLUDED.post_id,
+    updated_at = EXCLUDED.updated_at
+RETURNING id, created_at, updated_at, user_id, post_id, post_url, title, description, published_at, feed_name, note
+`
+
+type StarPostParams struct {
+	ID        uuid.UUID
+	CreatedAt time.Time
+	UserID    uuid.UUID
+	Note      sql.NullString
+	PostID    uuid.UUID
+}
+
+func (q *Queries) StarPost(ctx context.Context, arg StarPostParams) (PostStar, error) {
+	row := q.db.QueryRowContext(ctx, starPost,
+		arg.ID,
+		arg.CreatedAt,
+		arg.UserID,
+		arg.Note,
+		arg.PostID,
+	)
+	var i PostStar
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.UserID,
+		&i.PostID,
+		&i.PostUrl,
+		&i.Title,
+		&i.Description,
+		&i.PublishedAt,
+		&i.FeedName,
+		&i.Note,
+	)
+	return i, err
+}
+
+const unstarPost = `-- name: UnstarPost :execrows
+DELETE FROM post_stars
+WHERE user_id = $1 AND (id = $2 OR post_id = $2)
+`
+
+type UnstarPostParams struct {
+	UserID uuid.UUID
+	ID     uuid.UUID
+}
+
+func (q *Queries) UnstarPost(ctx context.Context, arg UnstarPostParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, unstarPost, arg.UserID, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,67 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: post_tombstones.sql
+
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const createPostTombstone = `-- name: CreatePostTombstone :exec
+INSERT INTO post_tombstones (feed_id, guid, pruned_at)
+VALUES ($1, $2, $3)
+ON CONFLICT (feed_id, guid) DO NOTHING
+`
+
+type CreatePostTombstoneParams struct {
+	FeedID   uuid.UUID
+	Guid     string
+	PrunedAt time.Time
+}
+
+func (q *Queries) CreatePostTombstone(ctx context.Context, arg CreatePostTombstoneParams) error {
+	_, err := q.db.ExecContext(ctx, createPostTombstone, arg.FeedID, arg.Guid, arg.PrunedAt)
+	return err
+}
+
+const isPostTombstoned = `-- name: IsPostTombstoned :one
+SELECT EXISTS (
+    SELECT 1 FROM post_tombstones
+    WHERE feed_id = $1 AND guid = $2
+)
+`
+
+type IsPostTombstonedParams struct {
+	FeedID uuid.UUID
+	Guid   string
+}
+
+func (q *Queries) IsPostTombstoned(ctx context.Context, arg IsPostTombstonedParams) (bool, error) {
+	row := q.db.QueryRowContext(ctx, isPostTombstoned, arg.FeedID, arg.Guid)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
+const movePostTombstones = `-- name: MovePostTombstones :exec
+INSERT INTO post_tombstones (feed_id, guid, pruned_at)
+SELECT $1, t.guid, t.pruned_at
+FROM post_tombstones t
+WHERE t.feed_id = $2
+ON CONFLICT (feed_id, guid) DO NOTHING
+`
+
+type MovePostTombstonesParams struct {
+	ToFeedID   uuid.UUID
+	FromFeedID uuid.UUID
+}
+
+func (q *Queries) MovePostTombstones(ctx context.Context, arg MovePostTombstonesParams) error {
+	_, err := q.db.ExecContext(ctx, movePostTombstones, arg.ToFeedID, arg.FromFeedID)
+	return err
+}
@@ -14,7 +14,7 @@ import (
 )
 
 const createPost = `-- name: CreatePost :one
-INSERT INTO posts (id, created_at, updated_at, title, url, description, published_at, feed_id)
+INSERT INTO posts (id, created_at, updated_at, title, url, description, published_at, feed_id, content, author, categories, guid)
 VALUES (
     $1,
     $2,
@@ -23,9 +23,13 @@ VALUES (
     $5,
     $6,
     $7,
-    $8
+    $8,
+    $9,
+    $10,
+    $11,
+    $12
 )
-RETURNING id, created_at, updated_at, title, url, description, published_at, feed_id
+RETURNING id, created_at, updated_at, title, url, description, published_at, feed_id, content, search_vector, author, categories, guid
 `
 
 type CreatePostParams struct {
@@ -37,6 +41,10 @@ type CreatePostParams struct {
 	Description sql.NullString
 	PublishedAt sql.NullTime
 	FeedID      uuid.UUID
+	Content     sql.NullString
+	Author      sql.NullString
+	Categories  sql.NullString
+	Guid        sql.NullString
 }
 
 func (q *Queries) CreatePost(ctx context.Context, arg CreatePostParams) (Post, error) {
@@ -49,6 +57,10 @@ func (q *Queries) CreatePost(ctx context.Context, arg CreatePostParams) (Post, e
 		arg.Description,
 		arg.PublishedAt,
 		arg.FeedID,
+		arg.Content,
+		arg.Author,
+		arg.Categories,
+		arg.Guid,
 	)
 	var i Post
 	err := row.Scan(
@@ -60,32 +72,102 @@ func (q *Queries) CreatePost(ctx context.Context, arg CreatePostParams) (Post, e
 		&i.Description,
 		&i.PublishedAt,
 		&i.FeedID,
+		&i.Content,
+		&i.SearchVector,
+		&i.Author,
+		&i.Categories,
+		&i.Guid,
 	)
 	return i, err
 }
 
+const deletePost = `-- name: DeletePost :exec
+DELETE FROM posts
+WHERE id = $1
+`
+
+func (q *Queries) DeletePost(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, deletePost, id)
+	return err
+}
+
 const getPostsForUser = `-- name: GetPostsForUser :many
-SELECT 
-    p.id,
-    p.created_at,
-    p.updated_at,
-    p.title,
-    p.url,
-    p.description,
-    p.published_at,
-    p.feed_id,
-    f.name as feed_name
-FROM posts p
-JOIN feeds f ON p.feed_id = f.id
-JOIN feed_follows ff ON f.id = ff.feed_id
-WHERE ff.user_id = $1
-ORDER BY p.published_at DESC NULLS LAST, p.created_at DESC
-LIMIT $2
+WITH timeline AS (
+    SELECT
+        p.id,
+        p.created_at,
+        p.updated_at,
+        p.title,
+        p.url,
+        p.description,
+        p.published_at,
+        p.feed_id,
+        COALESCE(ff.custom_title, f.name)::text as feed_name,
+        pr.read_at,
+        ps.created_at as starred_at,
+        COALESCE((
+            SELECT string_agg(pt.tag, ', ' ORDER BY pt.tag) FROM post_tags pt
+            WHERE pt.post_id = p.id AND pt.user_id = ff.user_id
+        ), '')::text as tags,
+        CASE
+            WHEN $7::text = 'fetched' THEN p.created_at
+            ELSE COALESCE(p.published_at, p.created_at)
+        END::timestamptz as sort_at
+    FROM posts p
+    JOIN feeds f ON p.feed_id = f.id
+    JOIN feed_follows ff ON f.id = ff.feed_id
+    LEFT JOIN post_reads pr ON pr.post_id = p.id AND pr.user_id = ff.user_id
+    LEFT JOIN post_stars ps ON ps.post_id = p.id AND ps.user_id = ff.user_id
+    WHERE ff.user_id = $8
+      AND (NOT $9::boolean OR pr.post_id IS NULL)
+      AND ($10::uuid IS NULL OR p.feed_id = $10)
+      -- Muted feeds only show up when asked for by name
+      AND (NOT ff.muted OR $10::uuid IS NOT NULL)
+      AND NOT EXISTS (
+          SELECT 1 FROM post_hides ph
+          WHERE ph.post_id = p.id AND ph.user_id = ff.user_id
+      )
+      AND (
+          $11::text IS NULL
+          OR EXISTS (
+              SELECT 1 FROM post_tags pt
+              WHERE pt.post_id = p.id AND pt.user_id = ff.user_id AND pt.tag = $11
+          )
+      )
+      AND (
+          $12::text IS NULL
+          OR EXISTS (
+              SELECT 1 FROM feed_follow_tags t
+              WHERE t.feed_follow_id = ff.id
+                AND (t.tag = $12 OR starts_with(t.tag, $12 || '/'))
+          )
+      )
+)
+SELECT id, created_at, updated_at, title, url, description, published_at, feed_id, feed_name, read_at, starred_at, tags, sort_at FROM timeline
+WHERE ($1::timestamptz IS NULL OR sort_at >= $1)
+  AND ($2::timestamptz IS NULL OR sort_at < $2)
+  AND (
+      $3::timestamptz IS NULL
+      OR (sort_at, id) < ($3, $4::uuid)
+  )
+ORDER BY sort_at DESC, id DESC
+LIMIT $6
+OFFSET $5
 `
 
 type GetPostsForUserParams struct {
-	UserID uuid.UUID
-	Limit  int32
+	Since       sql.NullTime
+	Until       sql.NullTime
+	AfterSortAt sql.NullTime
+	AfterID     uuid.NullUUID
+	Offset      int32
+	Limit       int32
+	SortBy      string
+	UserID      uuid.UUID
+	UnreadOnly  bool
+	FeedID      uuid.NullUUID
+	Tag         sql.NullString
+	Folder      sql.NullString
 }
 
 type GetPostsForUserRow struct {
@@ -98,10 +180,29 @@ type GetPostsForUserRow struct {
 	PublishedAt sql.NullTime
 	FeedID      uuid.UUID
 	FeedName    string
+	ReadAt      sql.NullTime
+	StarredAt   sql.NullTime
+	Tags        string
+	SortAt      time.Time
 }
 
+// Posts are ordered by a sort time (published, falling back to fetched, or fetched) and ID,
+// so a page can continue strictly after the last (sort_at, id) pair of the previous one.
 func (q *Queries) GetPostsForUser(ctx context.Context, arg GetPostsForUserParams) ([]GetPostsForUserRow, error) {
-	rows, err := q.db.QueryContext(ctx, getPostsForUser, arg.UserID, arg.Limit)
+	rows, err := q.db.QueryContext(ctx, getPostsForUser,
+		arg.Since,
+		arg.Until,
+		arg.AfterSortAt,
+		arg.AfterID,
+		arg.Offset,
+		arg.Limit,
+		arg.SortBy,
+		arg.UserID,
+		arg.UnreadOnly,
+		arg.FeedID,
+		arg.Tag,
+		arg.Folder,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -119,6 +220,205 @@ func (q *Queries) GetPostsForUser(ctx context.Context, arg GetPostsForUserParams
 			&i.PublishedAt,
 			&i.FeedID,
 			&i.FeedName,
+			&i.ReadAt,
+			&i.StarredAt,
+			&i.Tags,
+			&i.SortAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPrunablePosts = `-- name: GetPrunablePosts :many
+WITH ranked AS (
+    SELECT
+        p.id,
+        p.feed_id,
+        p.title,
+        p.url,
+        p.guid,
+        COALESCE(p.published_at, p.created_at) as posted_at,
+        ROW_NUMBER() OVER (
+            PARTITION BY p.feed_id
+            ORDER BY COALESCE(p.published_at, p.created_at) DESC, p.id DESC
+        ) as position
+    FROM posts p
+)
+SELECT
+    r.id,
+    r.feed_id,
+    r.title,
+    r.url,
+    r.guid,
+    f.name as feed_name
+FROM ranked r
+JOIN feeds f ON r.feed_id = f.id
+WHERE NOT EXISTS (
+    SELECT 1 FROM post_stars ps WHERE ps.post_id = r.id
+)
+  AND (
+      (
+          COALESCE(f.retention_max_age_days, $1::int) > 0
+          AND r.posted_at < $2::timestamptz
+              - make_interval(days => COALESCE(f.retention_max_age_days, $1::int))
+      )
+      OR (
+          COALESCE(f.retention_max_posts, $3::int) > 0
+          AND r.position > COALESCE(f.retention_max_posts, $3::int)
+      )
+  )
+ORDER BY f.name, r.position
+`
+
+type GetPrunablePostsParams struct {
+	DefaultMaxAgeDays int32
+	Now               time.Time
+	DefaultMaxPosts   int32
+}
+
+type GetPrunablePostsRow struct {
+	ID       uuid.UUID
+	FeedID   uuid.UUID
+	Title    string
+	Url      string
+	Guid     sql.NullString
+	FeedName string
+}
+
+// Posts past their feed's retention policy, falling back to the global policy when the feed
+// has none. A limit of 0 keeps posts forever, and starred posts are never pruned.
+func (q *Queries) GetPrunablePosts(ctx context.Context, arg GetPrunablePostsParams) ([]GetPrunablePostsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getPrunablePosts, arg.DefaultMaxAgeDays, arg.Now, arg.DefaultMaxPosts)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetPrunablePostsRow
+	for rows.Next() {
+		var i GetPrunablePostsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.FeedID,
+			&i.Title,
+			&i.Url,
+			&i.Guid,
+			&i.FeedName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const movePosts = `-- name: MovePosts :execrows
+UPDATE posts
+SET feed_id = $1, updated_at = NOW()
+WHERE feed_id = $2
+`
+
+type MovePostsParams struct {
+	ToFeedID   uuid.UUID
+	FromFeedID uuid.UUID
+}
+
+func (q *Queries) MovePosts(ctx context.Context, arg MovePostsParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, movePosts, arg.ToFeedID, arg.FromFeedID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const searchPosts = `-- name: SearchPosts :many
+SELECT
+    p.id,
+    p.title,
+    p.url,
+    p.published_at,
+    p.created_at,
+    p.feed_id,
+    COALESCE(ff.custom_title, f.name)::text as feed_name,
+    ts_rank(p.search_vector, websearch_to_tsquery('english', $1)) as rank,
+    ts_headline(
+        'english',
+        coalesce(p.description, '') || ' ' || coalesce(p.content, ''),
+        websearch_to_tsquery('english', $1),
+        'StartSel=**, StopSel=**, MaxFragments=2, MaxWords=20, MinWords=8'
+    )::text as snippet
+FROM posts p
+JOIN feeds f ON p.feed_id = f.id
+LEFT JOIN feed_follows ff ON ff.feed_id = p.feed_id AND ff.user_id = $2
+WHERE p.search_vector @@ websearch_to_tsquery('english', $1)
+  AND ($3::boolean OR ff.id IS NOT NULL)
+  AND ($4::uuid IS NULL OR p.feed_id = $4)
+  AND ($5::timestamptz IS NULL OR COALESCE(p.published_at, p.created_at) >= $5)
+ORDER BY rank DESC, COALESCE(p.published_at, p.created_at) DESC
+LIMIT $6
+`
+
+type SearchPostsParams struct {
+	Query    string
+	UserID   uuid.UUID
+	AllFeeds bool
+	FeedID   uuid.NullUUID
+	Since    sql.NullTime
+	Limit    int32
+}
+
+type SearchPostsRow struct {
+	ID          uuid.UUID
+	Title       string
+	Url         string
+	PublishedAt sql.NullTime
+	CreatedAt   time.Time
+	FeedID      uuid.UUID
+	FeedName    string
+	Rank        float32
+	Snippet     string
+}
+
+func (q *Queries) SearchPosts(ctx context.Context, arg SearchPostsParams) ([]SearchPostsRow, error) {
+	rows, err := q.db.QueryContext(ctx, searchPosts,
+		arg.Query,
+		arg.UserID,
+		arg.AllFeeds,
+		arg.FeedID,
+		arg.Since,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SearchPostsRow
+	for rows.Next() {
+		var i SearchPostsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Url,
+			&i.PublishedAt,
+			&i.CreatedAt,
+			&i.FeedID,
+			&i.FeedName,
+			&i.Rank,
+			&i.Snippet,
 		); err != nil {
 			return nil, err
 		}
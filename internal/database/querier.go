@@ -0,0 +1,127 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Querier interface {
+	AddFeedFollowTag(ctx context.Context, arg AddFeedFollowTagParams) error
+	CountAdmins(ctx context.Context) (int64, error)
+	CreateFeed(ctx context.Context, arg CreateFeedParams) (Feed, error)
+	CreateFeedFollow(ctx context.Context, arg CreateFeedFollowParams) (CreateFeedFollowRow, error)
+	CreatePost(ctx context.Context, arg CreatePostParams) (Post, error)
+	CreatePostTombstone(ctx context.Context, arg CreatePostTombstoneParams) error
+	CreateRule(ctx context.Context, arg CreateRuleParams) (Rule, error)
+	CreateSession(ctx context.Context, arg CreateSessionParams) (Session, error)
+	// The first user to register becomes an admin
+	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
+	DeleteAllFeeds(ctx context.Context) error
+	DeleteAllPosts(ctx context.Context) error
+	DeleteAllUsers(ctx context.Context) error
+	DeleteExpiredSessions(ctx context.Context, expiresAt time.Time) error
+	DeleteFeed(ctx context.Context, id uuid.UUID) error
+	DeleteFeedFollow(ctx context.Context, arg DeleteFeedFollowParams) error
+	DeletePost(ctx context.Context, id uuid.UUID) error
+	DeleteRule(ctx context.Context, arg DeleteRuleParams) (int64, error)
+	DeleteSession(ctx context.Context, tokenHash string) error
+	DeleteUser(ctx context.Context, id uuid.UUID) error
+	DeleteUserFeedFollows(ctx context.Context, userID uuid.UUID) error
+	DeleteUserPostReads(ctx context.Context, userID uuid.UUID) error
+	DeleteUserPostStars(ctx context.Context, userID uuid.UUID) error
+	DeleteUserPostTags(ctx context.Context, userID uuid.UUID) error
+	DeleteUserRules(ctx context.Context, userID uuid.UUID) error
+	DeleteUserSessions(ctx context.Context, userID uuid.UUID) error
+	FindRestoredFeed(ctx context.Context, arg FindRestoredFeedParams) (uuid.UUID, error)
+	FindRestoredFeedFollow(ctx context.Context, arg FindRestoredFeedFollowParams) (uuid.UUID, error)
+	FindRestoredPost(ctx context.Context, arg FindRestoredPostParams) (uuid.UUID, error)
+	// The existing user a clashing record stands for: the same ID, or else the same name
+	FindRestoredUser(ctx context.Context, arg FindRestoredUserParams) (uuid.UUID, error)
+	GetAllFeedFollowTags(ctx context.Context) ([]FeedFollowTag, error)
+	GetAllFeedFollows(ctx context.Context) ([]FeedFollow, error)
+	GetAllFeeds(ctx context.Context) ([]Feed, error)
+	GetAllPostHides(ctx context.Context) ([]PostHide, error)
+	GetAllPostReads(ctx context.Context) ([]PostRead, error)
+	GetAllPostStars(ctx context.Context) ([]PostStar, error)
+	GetAllPostTags(ctx context.Context) ([]PostTag, error)
+	GetAllPostTombstones(ctx context.Context) ([]PostTombstone, error)
+	GetAllPosts(ctx context.Context) ([]GetAllPostsRow, error)
+	GetAllRules(ctx context.Context) ([]Rule, error)
+	GetFeedByURL(ctx context.Context, url string) (Feed, error)
+	GetFeedByURLKey(ctx context.Context, urlKey sql.NullString) (Feed, error)
+	GetFeedFollow(ctx context.Context, arg GetFeedFollowParams) (FeedFollow, error)
+	GetFeedFollowTagsForUser(ctx context.Context, userID uuid.UUID) ([]GetFeedFollowTagsForUserRow, error)
+	GetFeedFollowersToNotify(ctx context.Context, feedID uuid.UUID) ([]GetFeedFollowersToNotifyRow, error)
+	GetFeedFollowsForUser(ctx context.Context, userID uuid.UUID) ([]GetFeedFollowsForUserRow, error)
+	GetFeedIcon(ctx context.Context, feedID uuid.UUID) (FeedIcon, error)
+	GetFeedStats(ctx context.Context, feedID uuid.UUID) (GetFeedStatsRow, error)
+	GetFeeds(ctx context.Context) ([]GetFeedsRow, error)
+	GetNextFeedToFetch(ctx context.Context) (Feed, error)
+	// Posts of the feeds a user follows, with the fields rules can match against
+	GetPostsForRules(ctx context.Context, userID uuid.UUID) ([]GetPostsForRulesRow, error)
+	// Posts are ordered by a sort time (published, falling back to fetched, or fetched) and ID,
+	// so a page can continue strictly after the last (sort_at, id) pair of the previous one.
+	GetPostsForUser(ctx context.Context, arg GetPostsForUserParams) ([]GetPostsForUserRow, error)
+	// Posts past their feed's retention policy, falling back to the global policy when the feed
+	// has none. A limit of 0 keeps posts forever, and starred posts are never pruned.
+	GetPrunablePosts(ctx context.Context, arg GetPrunablePostsParams) ([]GetPrunablePostsRow, error)
+	// Rules of every user following the feed, applied when its posts are fetched, with the
+	// name the user follows the feed under
+	GetRulesForFeed(ctx context.Context, feedID uuid.UUID) ([]GetRulesForFeedRow, error)
+	GetRulesForUser(ctx context.Context, userID uuid.UUID) ([]Rule, error)
+	GetSessionUser(ctx context.Context, arg GetSessionUserParams) (GetSessionUserRow, error)
+	GetStarredPosts(ctx context.Context, userID uuid.UUID) ([]PostStar, error)
+	GetTagsForUser(ctx context.Context, userID uuid.UUID) ([]GetTagsForUserRow, error)
+	GetUser(ctx context.Context, name string) (User, error)
+	GetUserStats(ctx context.Context, userID uuid.UUID) (GetUserStatsRow, error)
+	GetUsers(ctx context.Context) ([]User, error)
+	HidePost(ctx context.Context, arg HidePostParams) error
+	IsPostTombstoned(ctx context.Context, arg IsPostTombstonedParams) (bool, error)
+	MarkFeedFetched(ctx context.Context, id uuid.UUID) error
+	MarkFeedIconFetched(ctx context.Context, arg MarkFeedIconFetchedParams) error
+	MarkPostsRead(ctx context.Context, arg MarkPostsReadParams) (int64, error)
+	MoveFeedFollowTags(ctx context.Context, arg MoveFeedFollowTagsParams) error
+	MoveFeedFollows(ctx context.Context, arg MoveFeedFollowsParams) (int64, error)
+	MovePostTombstones(ctx context.Context, arg MovePostTombstonesParams) error
+	MovePosts(ctx context.Context, arg MovePostsParams) (int64, error)
+	RemoveFeedFollowTag(ctx context.Context, arg RemoveFeedFollowTagParams) (int64, error)
+	RenameUser(ctx context.Context, arg RenameUserParams) (User, error)
+	RestoreFeed(ctx context.Context, arg RestoreFeedParams) (uuid.UUID, error)
+	RestoreFeedFollow(ctx context.Context, arg RestoreFeedFollowParams) (uuid.UUID, error)
+	RestoreFeedFollowTag(ctx context.Context, arg RestoreFeedFollowTagParams) (int64, error)
+	RestorePost(ctx context.Context, arg RestorePostParams) (uuid.UUID, error)
+	RestorePostHide(ctx context.Context, arg RestorePostHideParams) (int64, error)
+	RestorePostRead(ctx context.Context, arg RestorePostReadParams) (int64, error)
+	RestorePostStar(ctx context.Context, arg RestorePostStarParams) (int64, error)
+	RestorePostTag(ctx context.Context, arg RestorePostTagParams) (int64, error)
+	RestorePostTombstone(ctx context.Context, arg RestorePostTombstoneParams) (int64, error)
+	RestoreRule(ctx context.Context, arg RestoreRuleParams) (int64, error)
+	// Restores return no row when the record clashes with an existing one
+	RestoreUser(ctx context.Context, arg RestoreUserParams) (uuid.UUID, error)
+	SearchPosts(ctx context.Context, arg SearchPostsParams) ([]SearchPostsRow, error)
+	SetFeedContentHash(ctx context.Context, arg SetFeedContentHashParams) error
+	SetFeedRetention(ctx context.Context, arg SetFeedRetentionParams) (Feed, error)
+	SetFeedURLKey(ctx context.Context, arg SetFeedURLKeyParams) error
+	SetUserPassword(ctx context.Context, arg SetUserPasswordParams) error
+	SetUserRole(ctx context.Context, arg SetUserRoleParams) (User, error)
+	SetUserTimezone(ctx context.Context, arg SetUserTimezoneParams) (User, error)
+	StarPost(ctx context.Context, arg StarPostParams) (PostStar, error)
+	TagPost(ctx context.Context, arg TagPostParams) error
+	TouchSession(ctx context.Context, arg TouchSessionParams) error
+	TransferFeed(ctx context.Context, arg TransferFeedParams) error
+	TransferUserFeeds(ctx context.Context, arg TransferUserFeedsParams) (int64, error)
+	UnstarPost(ctx context.Context, arg UnstarPostParams) (int64, error)
+	UpdateFeed(ctx context.Context, arg UpdateFeedParams) (Feed, error)
+	UpdateFeedFollowSettings(ctx context.Context, arg UpdateFeedFollowSettingsParams) (FeedFollow, error)
+	UpdateFeedMetadata(ctx context.Context, arg UpdateFeedMetadataParams) error
+	UpsertFeedIcon(ctx context.Context, arg UpsertFeedIconParams) error
+}
+
+var _ Querier = (*Queries)(nil)
@@ -0,0 +1,888 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: backup.sql
+
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const deleteAllFeeds = `-- name: DeleteAllFeeds :exec
+DELETE FROM feeds
+`
+
+func (q *Queries) DeleteAllFeeds(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, deleteAllFeeds)
+	return err
+}
+
+const deleteAllPosts = `-- name: DeleteAllPosts :exec
+DELETE FROM posts
+`
+
+func (q *Queries) DeleteAllPosts(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, deleteAllPosts)
+	return err
+}
+
+const deleteUserFeedFollows = `-- name: DeleteUserFeedFollows :exec
+DELETE FROM feed_follows
+WHERE user_id = ?1
+`
+
+func (q *Queries) DeleteUserFeedFollows(ctx context.Context, userID uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, deleteUserFeedFollows, userID)
+	return err
+}
+
+const deleteUserPostReads = `-- name: DeleteUserPostReads :exec
+DELETE FROM post_reads
+WHERE user_id = ?1
+`
+
+func (q *Queries) DeleteUserPostReads(ctx context.Context, userID uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, deleteUserPostReads, userID)
+	return err
+}
+
+const deleteUserPostStars = `-- name: DeleteUserPostStars :exec
+DELETE FROM post_stars
+WHERE user_id = ?1
+`
+
+func (q *Queries) DeleteUserPostStars(ctx context.Context, userID uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, deleteUserPostStars, userID)
+	return err
+}
+
+const deleteUserPostTags = `-- name: DeleteUserPostTags :exec
+DELETE FROM post_tags
+WHERE user_id = ?1
+`
+
+func (q *Queries) DeleteUserPostTags(ctx context.Context, userID uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, deleteUserPostTags, userID)
+	return err
+}
+
+const deleteUserRules = `-- name: DeleteUserRules :exec
+DELETE FROM rules
+WHERE user_id = ?1
+`
+
+func (q *Queries) DeleteUserRules(ctx context.Context, userID uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, deleteUserRules, userID)
+	return err
+}
+
+const findRestoredFeed = `-- name: FindRestoredFeed :one
+SELECT id FROM feeds
+WHERE id = ?1 OR url = ?2 OR url_key = ?3
+ORDER BY id = ?1 DESC
+LIMIT 1
+`
+
+type FindRestoredFeedParams struct {
+	ID     uuid.UUID
+	Url    string
+	UrlKey sql.NullString
+}
+
+func (q *Queries) FindRestoredFeed(ctx context.Context, arg FindRestoredFeedParams) (uuid.UUID, error) {
+	row := q.db.QueryRowContext(ctx, findRestoredFeed, arg.ID, arg.Url, arg.UrlKey)
+	var id uuid.UUID
+	err := row.Scan(&id)
+	return id, err
+}
+
+const findRestoredFeedFollow = `-- name: FindRestoredFeedFollow :one
+SELECT id FROM feed_follows
+WHERE id = ?1 OR (user_id = ?2 AND feed_id = ?3)
+ORDER BY id = ?1 DESC
+LIMIT 1
+`
+
+type FindRestoredFeedFollowParams struct {
+	ID     uuid.UUID
+	UserID uuid.UUID
+	FeedID uuid.UUID
+}
+
+func (q *Queries) FindRestoredFeedFollow(ctx context.Context, arg FindRestoredFeedFollowParams) (uuid.UUID, error) {
+	row := q.db.QueryRowContext(ctx, findRestoredFeedFollow, arg.ID, arg.UserID, arg.FeedID)
+	var id uuid.UUID
+	err := row.Scan(&id)
+	return id, err
+}
+
+const findRestoredPost = `-- name: FindRestoredPost :one
+SELECT id FROM posts
+WHERE id = ?1 OR url = ?2
+ORDER BY id = ?1 DESC
+LIMIT 1
+`
+
+type FindRestoredPostParams struct {
+	ID  uuid.UUID
+	Url string
+}
+
+func (q *Queries) FindRestoredPost(ctx context.Context, arg FindRestoredPostParams) (uuid.UUID, error) {
+	row := q.db.QueryRowContext(ctx, findRestoredPost, arg.ID, arg.Url)
+	var id uuid.UUID
+	err := row.Scan(&id)
+	return id, err
+}
+
+const findRestoredUser = `-- name: FindRestoredUser :one
+SELECT id FROM users
+WHERE id = ?1 OR name = ?2
+ORDER BY id = ?1 DESC
+LIMIT 1
+`
+
+type FindRestoredUserParams struct {
+	ID   uuid.UUID
+	Name string
+}
+
+// The existing user a clashing record stands for: the same ID, or else the same name
+func (q *Queries) FindRestoredUser(ctx context.Context, arg FindRestoredUserParams) (uuid.UUID, error) {
+	row := q.db.QueryRowContext(ctx, findRestoredUser, arg.ID, arg.Name)
+	var id uuid.UUID
+	err := row.Scan(&id)
+	return id, err
+}
+
+const getAllFeedFollowTags = `-- name: GetAllFeedFollowTags :many
+SELECT feed_follow_id, tag, created_at FROM feed_follow_tags
+ORDER BY created_at, feed_follow_id, tag
+`
+
+func (q *Queries) GetAllFeedFollowTags(ctx context.Context) ([]FeedFollowTag, error) {
+	rows, err := q.db.QueryContext(ctx, getAllFeedFollowTags)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []FeedFollowTag
+	for rows.Next() {
+		var i FeedFollowTag
+		if err := rows.Scan(&i.FeedFollowID, &i.Tag, &i.CreatedAt); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAllFeedFollows = `-- name: GetAllFeedFollows :many
+SELECT id, created_at, updated_at, user_id, feed_id, custom_title, muted, priority, notify FROM feed_follows
+ORDER BY created_at, id
+`
+
+func (q *Queries) GetAllFeedFollows(ctx context.Context) ([]FeedFollow, error) {
+	rows, err := q.db.QueryContext(ctx, getAllFeedFollows)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []FeedFollow
+	for rows.Next() {
+		var i FeedFollow
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.UserID,
+			&i.FeedID,
+			&i.CustomTitle,
+			&i.Muted,
+			&i.Priority,
+			&i.Notify,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAllFeeds = `-- name: GetAllFeeds :many
+SELECT id, created_at, updated_at, name, url, user_id, last_fetched_at, title, description, link, language, image_url, paused, url_key, content_hash, retention_max_age_days, retention_max_posts FROM feeds
+ORDER BY created_at, id
+`
+
+func (q *Queries) GetAllFeeds(ctx context.Context) ([]Feed, error) {
+	rows, err := q.db.QueryContext(ctx, getAllFeeds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Feed
+	for rows.Next() {
+		var i Feed
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Name,
+			&i.Url,
+			&i.UserID,
+			&i.LastFetchedAt,
+			&i.Title,
+			&i.Description,
+			&i.Link,
+			&i.Language,
+			&i.ImageUrl,
+			&i.Paused,
+			&i.UrlKey,
+			&i.ContentHash,
+			&i.RetentionMaxAgeDays,
+			&i.RetentionMaxPosts,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAllPostHides = `-- name: GetAllPostHides :many
+SELECT user_id, post_id, rule_id, hidden_at FROM post_hides
+ORDER BY hidden_at, user_id, post_id
+`
+
+func (q *Queries) GetAllPostHides(ctx context.Context) ([]PostHide, error) {
+	rows, err := q.db.QueryContext(ctx, getAllPostHides)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []PostHide
+	for rows.Next() {
+		var i PostHide
+		if err := rows.Scan(
+			&i.UserID,
+			&i.PostID,
+			&i.RuleID,
+			&i.HiddenAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAllPostReads = `-- name: GetAllPostReads :many
+SELECT user_id, post_id, read_at FROM post_reads
+ORDER BY read_at, user_id, post_id
+`
+
+func (q *Queries) GetAllPostReads(ctx context.Context) ([]PostRead, error) {
+	rows, err := q.db.QueryContext(ctx, getAllPostReads)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []PostRead
+	for rows.Next() {
+		var i PostRead
+		if err := rows.Scan(&i.UserID, &i.PostID, &i.ReadAt); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAllPostStars = `-- name: GetAllPostStars :many
+SELECT id, created_at, updated_at, user_id, post_id, post_url, title, description, published_at, feed_name, note FROM post_stars
+ORDER BY created_at, id
+`
+
+func (q *Queries) GetAllPostStars(ctx context.Context) ([]PostStar, error) {
+	rows, err := q.db.QueryContext(ctx, getAllPostStars)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []PostStar
+	for rows.Next() {
+		var i PostStar
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.UserID,
+			&i.PostID,
+			&i.PostUrl,
+			&i.Title,
+			&i.Description,
+			&i.PublishedAt,
+			&i.FeedName,
+			&i.Note,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAllPostTags = `-- name: GetAllPostTags :many
+SELECT user_id, post_id, tag, created_at FROM post_tags
+ORDER BY created_at, user_id, post_id, tag
+`
+
+func (q *Queries) GetAllPostTags(ctx context.Context) ([]PostTag, error) {
+	rows, err := q.db.QueryContext(ctx, getAllPostTags)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []PostTag
+	for rows.Next() {
+		var i PostTag
+		if err := rows.Scan(
+			&i.UserID,
+			&i.PostID,
+			&i.Tag,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAllPostTombstones = `-- name: GetAllPostTombstones :many
+SELECT feed_id, guid, pruned_at FROM post_tombstones
+ORDER BY pruned_at, feed_id, guid
+`
+
+func (q *Queries) GetAllPostTombstones(ctx context.Context) ([]PostTombstone, error) {
+	rows, err := q.db.QueryContext(ctx, getAllPostTombstones)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []PostTombstone
+	for rows.Next() {
+		var i PostTombstone
+		if err := rows.Scan(&i.FeedID, &i.Guid, &i.PrunedAt); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAllPosts = `-- name: GetAllPosts :many
+SELECT
+    id,
+    created_at,
+    updated_at,
+    title,
+    url,
+    description,
+    published_at,
+    feed_id,
+    content,
+    author,
+    categories,
+    guid
+FROM posts
+ORDER BY created_at, id
+`
+
+func (q *Queries) GetAllPosts(ctx context.Context) ([]Post, error) {
+	rows, err := q.db.QueryContext(ctx, getAllPosts)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Post
+	for rows.Next() {
+		var i Post
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Title,
+			&i.Url,
+			&i.Description,
+			&i.PublishedAt,
+			&i.FeedID,
+			&i.Content,
+			&i.Author,
+			&i.Categories,
+			&i.Guid,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAllRules = `-- name: GetAllRules :many
+SELECT id, created_at, updated_at, user_id, field, match_type, pattern, "action", tag FROM rules
+ORDER BY created_at, id
+`
+
+func (q *Queries) GetAllRules(ctx context.Context) ([]Rule, error) {
+	rows, err := q.db.QueryContext(ctx, getAllRules)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Rule
+	for rows.Next() {
+		var i Rule
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.UserID,
+			&i.Field,
+			&i.MatchType,
+			&i.Pattern,
+			&i.Action,
+			&i.Tag,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const restoreFeed = `-- name: RestoreFeed :one
+INSERT INTO feeds (
+    id, created_at, updated_at, name, url, user_id, last_fetched_at,
+    title, description, link, language, image_url, paused, url_key, content_hash,
+    retention_max_age_days, retention_max_posts
+)
+VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7, ?8, ?9, ?10, ?11, ?12, ?13, ?14, ?15, ?16, ?17)
+ON CONFLICT DO NOTHING
+RETURNING id
+`
+
+type RestoreFeedParams struct {
+	ID                  uuid.UUID
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+	Name                string
+	Url                 string
+	UserID              uuid.UUID
+	LastFetchedAt       sql.NullTime
+	Title               sql.NullString
+	Description         sql.NullString
+	Link                sql.NullString
+	Language            sql.NullString
+	ImageUrl            sql.NullString
+	Paused              bool
+	UrlKey              sql.NullString
+	ContentHash         sql.NullString
+	RetentionMaxAgeDays sql.NullInt32
+	RetentionMaxPosts   sql.NullInt32
+}
+
+func (q *Queries) RestoreFeed(ctx context.Context, arg RestoreFeedParams) (uuid.UUID, error) {
+	row := q.db.QueryRowContext(ctx, restoreFeed,
+		arg.ID,
+		arg.CreatedAt,
+		arg.UpdatedAt,
+		arg.Name,
+		arg.Url,
+		arg.UserID,
+		arg.LastFetchedAt,
+		arg.Title,
+		arg.Description,
+		arg.Link,
+		arg.Language,
+		arg.ImageUrl,
+		arg.Paused,
+		arg.UrlKey,
+		arg.ContentHash,
+		arg.RetentionMaxAgeDays,
+		arg.RetentionMaxPosts,
+	)
+	var id uuid.UUID
+	err := row.Scan(&id)
+	return id, err
+}
+
+const restoreFeedFollow = `-- name: RestoreFeedFollow :one
+INSERT INTO feed_follows (id, created_at, updated_at, user_id, feed_id, custom_title, muted, priority, notify)
+VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7, ?8, ?9)
+ON CONFLICT DO NOTHING
+RETURNING id
+`
+
+type RestoreFeedFollowParams struct {
+	ID          uuid.UUID
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	UserID      uuid.UUID
+	FeedID      uuid.UUID
+	CustomTitle sql.NullString
+	Muted       bool
+	Priority    int32
+	Notify      bool
+}
+
+func (q *Queries) RestoreFeedFollow(ctx context.Context, arg RestoreFeedFollowParams) (uuid.UUID, error) {
+	row := q.db.QueryRowContext(ctx, restoreFeedFollow,
+		arg.ID,
+		arg.CreatedAt,
+		arg.UpdatedAt,
+		arg.UserID,
+		arg.FeedID,
+		arg.CustomTitle,
+		arg.Muted,
+		arg.Priority,
+		arg.Notify,
+	)
+	var id uuid.UUID
+	err := row.Scan(&id)
+	return id, err
+}
+
+const restoreFeedFollowTag = `-- name: RestoreFeedFollowTag :execrows
+INSERT INTO feed_follow_tags (feed_follow_id, tag, created_at)
+VALUES (?1, ?2, ?3)
+ON CONFLICT DO NOTHING
+`
+
+type RestoreFeedFollowTagParams struct {
+	FeedFollowID uuid.UUID
+	Tag          string
+	CreatedAt    time.Time
+}
+
+func (q *Queries) RestoreFeedFollowTag(ctx context.Context, arg RestoreFeedFollowTagParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, restoreFeedFollowTag, arg.FeedFollowID, arg.Tag, arg.CreatedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const restorePost = `-- name: RestorePost :one
+INSERT INTO posts (
+    id, created_at, updated_at, title, url, description, published_at, feed_id,
+    content, author, categories, guid
+)
+VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7, ?8, ?9, ?10, ?11, ?12)
+ON CONFLICT DO NOTHING
+RETURNING id
+`
+
+type RestorePostParams struct {
+	ID          uuid.UUID
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	Title       string
+	Url         string
+	Description sql.NullString
+	PublishedAt sql.NullTime
+	FeedID      uuid.UUID
+	Content     sql.NullString
+	Author      sql.NullString
+	Categories  sql.NullString
+	Guid        sql.NullString
+}
+
+func (q *Queries) RestorePost(ctx context.Context, arg RestorePostParams) (uuid.UUID, error) {
+	row := q.db.QueryRowContext(ctx, restorePost,
+		arg.ID,
+		arg.CreatedAt,
+		arg.UpdatedAt,
+		arg.Title,
+		arg.Url,
+		arg.Description,
+		arg.PublishedAt,
+		arg.FeedID,
+		arg.Content,
+		arg.Author,
+		arg.Categories,
+		arg.Guid,
+	)
+	var id uuid.UUID
+	err := row.Scan(&id)
+	return id, err
+}
+
+const restorePostHide = `-- name: RestorePostHide :execrows
+INSERT INTO post_hides (user_id, post_id, rule_id, hidden_at)
+VALUES (?1, ?2, ?3, ?4)
+ON CONFLICT DO NOTHING
+`
+
+type RestorePostHideParams struct {
+	UserID   uuid.UUID
+	PostID   uuid.UUID
+	RuleID   uuid.UUID
+	HiddenAt time.Time
+}
+
+func (q *Queries) RestorePostHide(ctx context.Context, arg RestorePostHideParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, restorePostHide,
+		arg.UserID,
+		arg.PostID,
+		arg.RuleID,
+		arg.HiddenAt,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const restorePostRead = `-- name: RestorePostRead :execrows
+INSERT INTO post_reads (user_id, post_id, read_at)
+VALUES (?1, ?2, ?3)
+ON CONFLICT DO NOTHING
+`
+
+type RestorePostReadParams struct {
+	UserID uuid.UUID
+	PostID uuid.UUID
+	ReadAt time.Time
+}
+
+func (q *Queries) RestorePostRead(ctx context.Context, arg RestorePostReadParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, restorePostRead, arg.UserID, arg.PostID, arg.ReadAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const restorePostStar = `-- name: RestorePostStar :execrows
+INSERT INTO post_stars (
+    id, created_at, updated_at, user_id, post_id, post_url, title, description,
+    published_at, feed_name, note
+)
+VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7, ?8, ?9, ?10, ?11)
+ON CONFLICT DO NOTHING
+`
+
+type RestorePostStarParams struct {
+	ID          uuid.UUID
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	UserID      uuid.UUID
+	PostID      uuid.NullUUID
+	PostUrl     string
+	Title       string
+	Description sql.NullString
+	PublishedAt sql.NullTime
+	FeedName    string
+	Note        sql.NullString
+}
+
+func (q *Queries) RestorePostStar(ctx context.Context, arg RestorePostStarParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, restorePostStar,
+		arg.ID,
+		arg.CreatedAt,
+		arg.UpdatedAt,
+		arg.UserID,
+		arg.PostID,
+		arg.PostUrl,
+		arg.Title,
+		arg.Description,
+		arg.PublishedAt,
+		arg.FeedName,
+		arg.Note,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const restorePostTag = `-- name: RestorePostTag :execrows
+INSERT INTO post_tags (user_id, post_id, tag, created_at)
+VALUES (?1, ?2, ?3, ?4)
+ON CONFLICT DO NOTHING
+`
+
+type RestorePostTagParams struct {
+	UserID    uuid.UUID
+	PostID    uuid.UUID
+	Tag       string
+	CreatedAt time.Time
+}
+
+func (q *Queries) RestorePostTag(ctx context.Context, arg RestorePostTagParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, restorePostTag,
+		arg.UserID,
+		arg.PostID,
+		arg.Tag,
+		arg.CreatedAt,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const restorePostTombstone = `-- name: RestorePostTombstone :execrows
+INSERT INTO post_tombstones (feed_id, guid, pruned_at)
+VALUES (?1, ?2, ?3)
+ON CONFLICT DO NOTHING
+`
+
+type RestorePostTombstoneParams struct {
+	FeedID   uuid.UUID
+	Guid     string
+	PrunedAt time.Time
+}
+
+func (q *Queries) RestorePostTombstone(ctx context.Context, arg RestorePostTombstoneParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, restorePostTombstone, arg.FeedID, arg.Guid, arg.PrunedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const restoreRule = `-- name: RestoreRule :execrows
+INSERT INTO rules (id, created_at, updated_at, user_id, field, match_type, pattern, action, tag)
+VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7, ?8, ?9)
+ON CONFLICT DO NOTHING
+`
+
+type RestoreRuleParams struct {
+	ID        uuid.UUID
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	UserID    uuid.UUID
+	Field     string
+	MatchType string
+	Pattern   string
+	Action    string
+	Tag       sql.NullString
+}
+
+func (q *Queries) RestoreRule(ctx context.Context, arg RestoreRuleParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, restoreRule,
+		arg.ID,
+		arg.CreatedAt,
+		arg.UpdatedAt,
+		arg.UserID,
+		arg.Field,
+		arg.MatchType,
+		arg.Pattern,
+		arg.Action,
+		arg.Tag,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const restoreUser = `-- name: RestoreUser :one
+INSERT INTO users (id, created_at, updated_at, name, role, timezone, password_hash)
+VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7)
+ON CONFLICT DO NOTHING
+RETURNING id
+`
+
+type RestoreUserParams struct {
+	ID           uuid.UUID
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	Name         string
+	Role         string
+	Timezone     sql.NullString
+	PasswordHash sql.NullString
+}
+
+// Restores return no row when the record clashes with an existing one
+func (q *Queries) RestoreUser(ctx context.Context, arg RestoreUserParams) (uuid.UUID, error) {
+	row := q.db.QueryRowContext(ctx, restoreUser,
+		arg.ID,
+		arg.CreatedAt,
+		arg.UpdatedAt,
+		arg.Name,
+		arg.Role,
+		arg.Timezone,
+		arg.PasswordHash,
+	)
+	var id uuid.UUID
+	err := row.Scan(&id)
+	return id, err
+}
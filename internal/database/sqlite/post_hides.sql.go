@@ -0,0 +1,36 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: post_hides.sql
+
+package sqlite
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const hidePost = `-- name: HidePost :exec
+INSERT INTO post_hides (user_id, post_id, rule_id, hidden_at)
+VALUES (?1, ?2, ?3, ?4)
+ON CONFLICT (user_id, post_id) DO NOTHING
+`
+
+type HidePostParams struct {
+	UserID   uuid.UUID
+	PostID   uuid.UUID
+	RuleID   uuid.UUID
+	HiddenAt time.Time
+}
+
+func (q *Queries) HidePost(ctx context.Context, arg HidePostParams) error {
+	_, err := q.db.ExecContext(ctx, hidePost,
+		arg.UserID,
+		arg.PostID,
+		arg.RuleID,
+		arg.HiddenAt,
+	)
+	return err
+}
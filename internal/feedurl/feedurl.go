@@ -0,0 +1,100 @@
+package feedurl
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+)
+
+// trackingParams are query parameters that identify a campaign rather than a resource
+var trackingParams = map[string]bool{
+	"fbclid":  true,
+	"gclid":   true,
+	"dclid":   true,
+	"msclkid": true,
+	"yclid":   true,
+	"igshid":  true,
+	"mc_cid":  true,
+	"mc_eid":  true,
+	"_hsenc":  true,
+	"_hsmi":   true,
+}
+
+// Normalize cleans up a feed URL while keeping it fetchable: it defaults the scheme to https,
+// lowercases the scheme and host, drops default ports, fragments and tracking parameters,
+// and sorts the remaining query parameters
+func Normalize(rawURL string) (string, error) {
+	parsed, err := parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+	return parsed.String(), nil
+}
+
+// Key returns the identity of a feed URL used to detect duplicates. On top of Normalize it
+// ignores the scheme, a leading "www." and trailing slashes, so http://x.com/feed,
+// https://x.com/feed/ and https://www.x.com/feed?utm_source=y share the key x.com/feed
+func Key(rawURL string) (string, error) {
+	parsed, err := parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+
+	key := strings.TrimPrefix(parsed.Host, "www.") + strings.TrimRight(parsed.EscapedPath(), "/")
+	if parsed.RawQuery != "" {
+		key += "?" + parsed.RawQuery
+	}
+	return key, nil
+}
+
+// parse parses and normalizes a feed URL
+func parse(rawURL string) (*url.URL, error) {
+	rawURL = strings.TrimSpace(rawURL)
+	if rawURL == "" {
+		return nil, fmt.Errorf("feed URL is empty")
+	}
+	if !strings.Contains(rawURL, "://") {
+		rawURL = "https://" + rawURL
+	}
+
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid feed URL '%s': %w", rawURL, err)
+	}
+	parsed.Scheme = strings.ToLower(parsed.Scheme)
+	if (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return nil, fmt.Errorf("invalid feed URL '%s'", rawURL)
+	}
+
+	// Lowercase the host and drop the port if it is the scheme's default
+	host := strings.TrimSuffix(strings.ToLower(parsed.Hostname()), ".")
+	port := parsed.Port()
+	if port == "" || (parsed.Scheme == "http" && port == "80") || (parsed.Scheme == "https" && port == "443") {
+		parsed.Host = host
+		if strings.Contains(host, ":") {
+			// Keep IPv6 literals bracketed
+			parsed.Host = "[" + host + "]"
+		}
+	} else {
+		parsed.Host = net.JoinHostPort(host, port)
+	}
+
+	if parsed.Path == "" {
+		parsed.Path = "/"
+	}
+	parsed.Fragment = ""
+	parsed.RawFragment = ""
+
+	// Drop tracking parameters, Encode sorts what is left by key
+	query := parsed.Query()
+	for param := range query {
+		if strings.HasPrefix(strings.ToLower(param), "utm_") || trackingParams[strings.ToLower(param)] {
+			query.Del(param)
+		}
+	}
+	parsed.RawQuery = query.Encode()
+	parsed.ForceQuery = false
+
+	return parsed, nil
+}
@@ -0,0 +1,95 @@
+package feedurl
+
+import "testing"
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"already normal", "https://example.com/feed", "https://example.com/feed"},
+		{"scheme and host case", "HTTPS://Example.COM/Feed", "https://example.com/Feed"},
+		{"missing scheme", "example.com/feed", "https://example.com/feed"},
+		{"surrounding space", "  https://example.com/feed\n", "https://example.com/feed"},
+		{"default http port", "http://example.com:80/feed", "http://example.com/feed"},
+		{"default https port", "https://example.com:443/feed", "https://example.com/feed"},
+		{"other port", "https://example.com:8443/feed", "https://example.com:8443/feed"},
+		{"port of the other scheme", "http://example.com:443/feed", "http://example.com:443/feed"},
+		{"IPv6 default port", "https://[::1]:443/feed", "https://[::1]/feed"},
+		{"IPv6 other port", "https://[::1]:8443/feed", "https://[::1]:8443/feed"},
+		{"trailing dot in host", "https://example.com./feed", "https://example.com/feed"},
+		{"empty path", "https://example.com", "https://example.com/"},
+		{"trailing slash kept", "https://example.com/feed/", "https://example.com/feed/"},
+		{"www kept", "https://www.example.com/feed", "https://www.example.com/feed"},
+		{"query sorted", "https://example.com/feed?b=2&a=1", "https://example.com/feed?a=1&b=2"},
+		{"tracking parameters", "https://example.com/feed?utm_source=x&id=3&fbclid=y&UTM_Medium=z", "https://example.com/feed?id=3"},
+		{"empty query", "https://example.com/feed?", "https://example.com/feed"},
+		{"fragment", "https://example.com/feed#latest", "https://example.com/feed"},
+		{"escaped path", "https://example.com/a%20b", "https://example.com/a%20b"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Normalize(tt.in)
+			if err != nil {
+				t.Fatalf("Normalize(%q) returned error: %v", tt.in, err)
+			}
+			if got != tt.want {
+				t.Errorf("Normalize(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKey(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"plain", "https://example.com/feed", "example.com/feed"},
+		{"http", "http://example.com/feed", "example.com/feed"},
+		{"trailing slash", "https://example.com/feed/", "example.com/feed"},
+		{"trailing slashes", "https://example.com/feed//", "example.com/feed"},
+		{"www", "https://www.example.com/feed", "example.com/feed"},
+		{"only leading www", "https://www2.example.com/feed", "www2.example.com/feed"},
+		{"everything at once", "HTTP://WWW.Example.com:80/feed/?utm_source=y#top", "example.com/feed"},
+		{"root", "https://example.com", "example.com"},
+		{"root with slash", "https://example.com/", "example.com"},
+		{"path case kept", "https://example.com/Feed", "example.com/Feed"},
+		{"other port", "https://example.com:8080/feed", "example.com:8080/feed"},
+		{"query sorted", "https://example.com/feed?b=2&a=1", "example.com/feed?a=1&b=2"},
+		{"query kept after slash", "https://example.com/feed/?id=3", "example.com/feed?id=3"},
+		{"missing scheme", "example.com/feed", "example.com/feed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Key(tt.in)
+			if err != nil {
+				t.Fatalf("Key(%q) returned error: %v", tt.in, err)
+			}
+			if got != tt.want {
+				t.Errorf("Key(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInvalidURLs(t *testing.T) {
+	for _, in := range []string{
+		"",
+		"   ",
+		"ftp://example.com/feed",
+		"https://",
+		"https:///feed",
+		"http://exa mple.com/feed",
+		"https://example.com:port/feed",
+	} {
+		if got, err := Normalize(in); err == nil {
+			t.Errorf("Normalize(%q) = %q, want an error", in, got)
+		}
+		if got, err := Key(in); err == nil {
+			t.Errorf("Key(%q) = %q, want an error", in, got)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package migrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+
+	"github.com/pressly/goose/v3"
+	"github.com/PassZ/rss-aggregator/internal/store"
+	"github.com/PassZ/rss-aggregator/sql/schema"
+	sqliteschema "github.com/PassZ/rss-aggregator/sql/sqlite/schema"
+)
+
+// ErrSchemaBehind is returned by Check when the database needs migrating
+var ErrSchemaBehind = errors.New("database schema is behind")
+
+// NewProvider returns a goose provider for the embedded migrations of the store's backend.
+// It uses the same goose_db_version table as the goose CLI, so databases migrated by hand
+// carry on from where they are.
+func NewProvider(st store.Store) (*goose.Provider, error) {
+	dialect, migrations := goose.DialectPostgres, fs.FS(schema.FS)
+	if st.Driver() == store.DriverSQLite {
+		dialect, migrations = goose.DialectSQLite3, sqliteschema.FS
+	}
+
+	provider, err := goose.NewProvider(dialect, st.DB(), migrations)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load migrations: %w", err)
+	}
+	return provider, nil
+}
+
+// Check returns ErrSchemaBehind, wrapped with the versions involved, when the database
+// is missing migrations that this binary ships with
+func Check(ctx context.Context, st store.Store) error {
+	provider, err := NewProvider(st)
+	if err != nil {
+		return err
+	}
+
+	current, target, err := provider.GetVersions(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get schema version: %w", err)
+	}
+	if current < target {
+		return fmt.Errorf("%w: it is at version %d but this gator needs version %d, run 'gator migrate up'",
+			ErrSchemaBehind, current, target)
+	}
+	return nil
+}
@@ -0,0 +1,219 @@
+package opml
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Document represents an OPML 1.0 or 2.0 document
+type Document struct {
+	XMLName xml.Name `xml:"opml"`
+	Version string   `xml:"version,attr"`
+	Head    Head     `xml:"head"`
+	Body    Body     `xml:"body"`
+}
+
+// Head holds the document metadata
+type Head struct {
+	Title       string `xml:"title,omitempty"`
+	DateCreated string `xml:"dateCreated,omitempty"`
+}
+
+// Body holds the top-level outlines
+type Body struct {
+	Outlines []Outline `xml:"outline"`
+}
+
+// Outline is either a feed subscription (it has an xmlUrl) or a folder of outlines
+type Outline struct {
+	Text     string    `xml:"text,attr"`
+	Title    string    `xml:"title,attr,omitempty"`
+	Type     string    `xml:"type,attr,omitempty"`
+	XMLURL   string    `xml:"xmlUrl,attr,omitempty"`
+	HTMLURL  string    `xml:"htmlUrl,attr,omitempty"`
+	URL      string    `xml:"url,attr,omitempty"`
+	Outlines []Outline `xml:"outline"`
+}
+
+// Subscription is a feed outline flattened together with the folder it was nested in
+type Subscription struct {
+	Title   string
+	XMLURL  string
+	HTMLURL string
+	// Folder is the path of the enclosing outlines joined by "/", empty at the top level
+	Folder string
+}
+
+// Parse reads an OPML document
+func Parse(r io.Reader) (*Document, error) {
+	decoder := xml.NewDecoder(r)
+	decoder.CharsetReader = charsetReader
+	// Exports from older readers are often sloppy about escaping
+	decoder.Strict = false
+	decoder.Entity = xml.HTMLEntity
+
+	var doc Document
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to parse OPML: %w", err)
+	}
+	if doc.XMLName.Local != "opml" {
+		return nil, fmt.Errorf("not an OPML document")
+	}
+
+	return &doc, nil
+}
+
+// Subscriptions flattens the outline tree into feed subscriptions.
+// Outlines without a feed URL and without children are returned with an empty XMLURL
+// so callers can report them as invalid.
+func (d *Document) Subscriptions() []Subscription {
+	var subs []Subscription
+	var walk func(outlines []Outline, folder string)
+	walk = func(outlines []Outline, folder string) {
+		for _, outline := range outlines {
+			feedURL := strings.TrimSpace(outline.XMLURL)
+			// OPML 1.0 exports from some readers use url instead of xmlUrl
+			if feedURL == "" && outline.Type == "rss" {
+				feedURL = strings.TrimSpace(outline.URL)
+			}
+
+			if feedURL == "" && len(outline.Outlines) > 0 {
+				walk(outline.Outlines, joinFolder(folder, outline.label()))
+				continue
+			}
+
+			subs = append(subs, Subscription{
+				Title:   outline.label(),
+				XMLURL:  feedURL,
+				HTMLURL: strings.TrimSpace(outline.HTMLURL),
+				Folder:  folder,
+			})
+		}
+	}
+	walk(d.Body.Outlines, "")
+	return subs
+}
+
+// NewDocument builds an OPML 2.0 document from subscriptions, nesting them by folder path
+func NewDocument(title string, subs []Subscription) *Document {
+	doc := &Document{
+		Version: "2.0",
+		Head: Head{
+			Title:       title,
+			DateCreated: time.Now().UTC().Format(time.RFC1123Z),
+		},
+	}
+
+	for _, sub := range subs {
+		outline := Outline{
+			Text:    sub.Title,
+			Title:   sub.Title,
+			Type:    "rss",
+			XMLURL:  sub.XMLURL,
+			HTMLURL: sub.HTMLURL,
+		}
+		parent := &doc.Body.Outlines
+		for _, name := range splitFolder(sub.Folder) {
+			parent = &folderOutline(parent, name).Outlines
+		}
+		*parent = append(*parent, outline)
+	}
+
+	return doc
+}
+
+// Write encodes the document with an XML header
+func Write(w io.Writer, doc *Document) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		return fmt.Errorf("failed to write OPML: %w", err)
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// folderOutline returns the folder outline with the given name, appending it if missing
+func folderOutline(outlines *[]Outline, name string) *Outline {
+	for i := range *outlines {
+		folder := &(*outlines)[i]
+		if folder.XMLURL == "" && folder.Text == name {
+			return folder
+		}
+	}
+	*outlines = append(*outlines, Outline{Text: name, Title: name})
+	return &(*outlines)[len(*outlines)-1]
+}
+
+// splitFolder splits a folder path into its names, ignoring empty segments
+func splitFolder(folder string) []string {
+	var names []string
+	for _, name := range strings.Split(folder, "/") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// label returns the display name of an outline, preferring title over text
+func (o Outline) label() string {
+	if title := strings.TrimSpace(o.Title); title != "" {
+		return title
+	}
+	return strings.TrimSpace(o.Text)
+}
+
+// joinFolder appends a folder name to a folder path
+func joinFolder(parent, name string) string {
+	name = strings.ReplaceAll(name, "/", "-")
+	if parent == "" {
+		return name
+	}
+	if name == "" {
+		return parent
+	}
+	return parent + "/" + name
+}
+
+// windows1252 maps the bytes 0x80 to 0x9f of windows-1252 to Unicode, where it differs
+// from Latin-1 by using them for printable characters such as curly quotes and the euro
+// sign. Files labelled Latin-1 are decoded the same way, as browsers do, because they
+// are almost always windows-1252. The five unassigned bytes are kept as they are.
+var windows1252 = [32]rune{
+	'\u20ac', '\u0081', '\u201a', '\u0192', '\u201e', '\u2026', '\u2020', '\u2021',
+	'\u02c6', '\u2030', '\u0160', '\u2039', '\u0152', '\u008d', '\u017d', '\u008f',
+	'\u0090', '\u2018', '\u2019', '\u201c', '\u201d', '\u2022', '\u2013', '\u2014',
+	'\u02dc', '\u2122', '\u0161', '\u203a', '\u0153', '\u009d', '\u017e', '\u0178',
+}
+
+// charsetReader handles the legacy single-byte encodings found in older OPML exports
+func charsetReader(charset string, input io.Reader) (io.Reader, error) {
+	switch strings.ToLower(charset) {
+	case "utf-8", "utf8", "us-ascii", "ascii":
+		return input, nil
+	case "iso-8859-1", "latin1", "latin-1", "windows-1252", "cp1252":
+		data, err := io.ReadAll(input)
+		if err != nil {
+			return nil, err
+		}
+		var buf bytes.Buffer
+		for _, b := range data {
+			if b >= 0x80 && b < 0xa0 {
+				buf.WriteRune(windows1252[b-0x80])
+				continue
+			}
+			buf.WriteRune(rune(b))
+		}
+		return &buf, nil
+	default:
+		return nil, fmt.Errorf("unsupported charset: %s", charset)
+	}
+}
@@ -0,0 +1,135 @@
+package opml
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseWindows1252(t *testing.T) {
+	// \x93 and \x94 are curly quotes, \x80 the euro sign and \xe9 an e with an acute accent,
+	// the same in Latin-1 and windows-1252
+	for _, charset := range []string{"windows-1252", "ISO-8859-1"} {
+		t.Run(charset, func(t *testing.T) {
+			input := "<?xml version=\"1.0\" encoding=\"" + charset + "\"?>\n" +
+				"<opml version=\"1.0\"><head><title>Caf\xe9 feeds</title></head><body>\n" +
+				"<outline text=\"\x93Prices\x94 in \x80\" xmlUrl=\"https://example.com/feed\"/>\n" +
+				"</body></opml>"
+			doc, err := Parse(strings.NewReader(input))
+			if err != nil {
+				t.Fatalf("Parse returned error: %v", err)
+			}
+			if want := "Café feeds"; doc.Head.Title != want {
+				t.Errorf("got title %q, want %q", doc.Head.Title, want)
+			}
+			subs := doc.Subscriptions()
+			if len(subs) != 1 {
+				t.Fatalf("got %d subscription(s), want 1", len(subs))
+			}
+			if want := "“Prices” in €"; subs[0].Title != want {
+				t.Errorf("got outline %q, want %q", subs[0].Title, want)
+			}
+		})
+	}
+}
+
+func TestParseUnsupportedCharset(t *testing.T) {
+	input := `<?xml version="1.0" encoding="koi8-r"?><opml version="1.0"><body/></opml>`
+	if _, err := Parse(strings.NewReader(input)); err == nil {
+		t.Error("Parse succeeded with an unsupported charset")
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, input := range []string{
+		"",
+		"not xml at all",
+		`<rss version="2.0"><channel/></rss>`,
+	} {
+		if _, err := Parse(strings.NewReader(input)); err == nil {
+			t.Errorf("Parse(%q) succeeded, want an error", input)
+		}
+	}
+}
+
+func TestSubscriptionsNested(t *testing.T) {
+	input := `<?xml version="1.0" encoding="UTF-8"?>
+<opml version="2.0">
+  <head><title>Subscriptions</title></head>
+  <body>
+    <outline text="Top" xmlUrl=" https://example.com/top.xml " htmlUrl="https://example.com/"/>
+    <outline text="Tech">
+      <outline text="Go" title="The Go Blog" xmlUrl="https://go.dev/blog/feed.atom"/>
+      <outline text="Databases">
+        <outline text="Postgres" type="rss" url="https://example.com/pg.xml"/>
+      </outline>
+      <outline text="Broken"/>
+    </outline>
+    <outline text="News/Daily">
+      <outline text="Daily" xmlUrl="https://example.com/daily.xml"/>
+    </outline>
+    <outline text="Empty folder"></outline>
+  </body>
+</opml>`
+	doc, err := Parse(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+
+	want := []Subscription{
+		{Title: "Top", XMLURL: "https://example.com/top.xml", HTMLURL: "https://example.com/"},
+		{Title: "The Go Blog", XMLURL: "https://go.dev/blog/feed.atom", Folder: "Tech"},
+		{Title: "Postgres", XMLURL: "https://example.com/pg.xml", Folder: "Tech/Databases"},
+		// Outlines without a feed URL are kept so they can be reported
+		{Title: "Broken", Folder: "Tech"},
+		{Title: "Daily", XMLURL: "https://example.com/daily.xml", Folder: "News-Daily"},
+		{Title: "Empty folder"},
+	}
+	if got := doc.Subscriptions(); !reflect.DeepEqual(got, want) {
+		t.Errorf("got subscriptions\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestParseSloppyEscaping(t *testing.T) {
+	input := `<opml version="1.0"><body>
+<outline text="Q&A &mdash; weekly" xmlUrl="https://example.com/feed?a=1&b=2"/>
+</body></opml>`
+	doc, err := Parse(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+	subs := doc.Subscriptions()
+	if len(subs) != 1 || subs[0].XMLURL != "https://example.com/feed?a=1&b=2" {
+		t.Errorf("got subscriptions %+v", subs)
+	}
+}
+
+func TestWriteRoundTrip(t *testing.T) {
+	subs := []Subscription{
+		{Title: "Top", XMLURL: "https://example.com/top.xml", HTMLURL: "https://example.com/"},
+		{Title: "Go", XMLURL: "https://go.dev/blog/feed.atom", Folder: "Tech"},
+		{Title: "Postgres", XMLURL: "https://example.com/pg.xml", Folder: "Tech/Databases"},
+		{Title: "Rust", XMLURL: "https://example.com/rust.xml", Folder: "Tech"},
+	}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, NewDocument("Export", subs)); err != nil {
+		t.Fatalf("Write returned error: %v", err)
+	}
+	doc, err := Parse(&buf)
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+	if doc.Version != "2.0" || doc.Head.Title != "Export" {
+		t.Errorf("got version %q and title %q", doc.Version, doc.Head.Title)
+	}
+
+	// Folders are nested back into the same paths, in the same order
+	if got := doc.Subscriptions(); !reflect.DeepEqual(got, subs) {
+		t.Errorf("got subscriptions\n%+v\nwant\n%+v", got, subs)
+	}
+	if tech := doc.Body.Outlines[1]; tech.Text != "Tech" || len(tech.Outlines) != 3 {
+		t.Errorf("got folder %+v, want Tech holding two feeds and a folder", tech)
+	}
+}
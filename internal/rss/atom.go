@@ -0,0 +1,121 @@
+package rss
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+)
+
+const atomNamespace = "http://www.w3.org/2005/Atom"
+
+// atomFeed represents the parts of an Atom feed that map onto RSSFeed
+type atomFeed struct {
+	Title    string      `xml:"title"`
+	Subtitle string      `xml:"subtitle"`
+	Icon     string      `xml:"icon"`
+	Logo     string      `xml:"logo"`
+	Links    []atomLink  `xml:"link"`
+	Entries  []atomEntry `xml:"entry"`
+}
+
+// atomLink represents an Atom <link> element
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+}
+
+// atomEntry represents a single entry in an Atom feed
+type atomEntry struct {
+	ID         string         `xml:"id"`
+	Title      string         `xml:"title"`
+	Links      []atomLink     `xml:"link"`
+	Summary    string         `xml:"summary"`
+	Content    string         `xml:"content"`
+	Published  string         `xml:"published"`
+	Updated    string         `xml:"updated"`
+	Authors    []atomPerson   `xml:"author"`
+	Categories []atomCategory `xml:"category"`
+}
+
+// atomPerson represents an Atom <author> element
+type atomPerson struct {
+	Name string `xml:"name"`
+}
+
+// atomCategory represents an Atom <category> element
+type atomCategory struct {
+	Term  string `xml:"term,attr"`
+	Label string `xml:"label,attr"`
+}
+
+// isAtomFeed reports whether the document's root element is an Atom <feed>
+func isAtomFeed(body []byte) bool {
+	decoder := xml.NewDecoder(bytes.NewReader(body))
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return false
+		}
+		if start, ok := token.(xml.StartElement); ok {
+			return start.Name.Local == "feed" && start.Name.Space == atomNamespace
+		}
+	}
+}
+
+// parseAtomFeed parses an Atom document into the RSSFeed structure
+func parseAtomFeed(body []byte, feed *RSSFeed) error {
+	var atom atomFeed
+	if err := xml.Unmarshal(body, &atom); err != nil {
+		return err
+	}
+
+	feed.Channel.Title = atom.Title
+	feed.Channel.Link = alternateLink(atom.Links)
+	feed.Channel.Description = atom.Subtitle
+	feed.Channel.Image.URL = strings.TrimSpace(atom.Logo)
+	feed.Channel.Icon = strings.TrimSpace(atom.Icon)
+
+	for _, entry := range atom.Entries {
+		item := RSSItem{
+			Title:       entry.Title,
+			Link:        alternateLink(entry.Links),
+			Description: entry.Summary,
+			PubDate:     strings.TrimSpace(entry.Published),
+			Content:     entry.Content,
+			GUID:        strings.TrimSpace(entry.ID),
+		}
+		if item.Description == "" {
+			item.Description = entry.Content
+		}
+		if item.PubDate == "" {
+			item.PubDate = strings.TrimSpace(entry.Updated)
+		}
+		var authors []string
+		for _, author := range entry.Authors {
+			if name := strings.TrimSpace(author.Name); name != "" {
+				authors = append(authors, name)
+			}
+		}
+		item.Author = strings.Join(authors, ", ")
+		for _, category := range entry.Categories {
+			label := category.Label
+			if label == "" {
+				label = category.Term
+			}
+			item.Categories = append(item.Categories, label)
+		}
+		feed.Channel.Item = append(feed.Channel.Item, item)
+	}
+
+	return nil
+}
+
+// alternateLink returns the rel="alternate" link, which is the default relation in Atom
+func alternateLink(links []atomLink) string {
+	for _, link := range links {
+		if link.Rel == "" || link.Rel == "alternate" {
+			return link.Href
+		}
+	}
+	return ""
+}
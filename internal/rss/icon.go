@@ -0,0 +1,130 @@
+package rss
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// maxIconSize caps how many bytes we are willing to download for an icon
+const maxIconSize = 1 << 20
+
+// Icon represents a downloaded feed icon
+type Icon struct {
+	URL         string
+	ContentType string
+	Data        []byte
+}
+
+// IconCandidates returns the URLs worth trying for a feed's icon, best first:
+// the channel <image>, the Atom <icon>, then the site's /favicon.ico
+func IconCandidates(feed *RSSFeed, feedURL string) []string {
+	var candidates []string
+	seen := make(map[string]bool)
+	add := func(ref string) {
+		resolved := resolveURL(feedURL, ref)
+		if resolved != "" && !seen[resolved] {
+			seen[resolved] = true
+			candidates = append(candidates, resolved)
+		}
+	}
+
+	add(feed.Channel.Image.URL)
+	add(feed.Channel.Icon)
+
+	// Fall back to the favicon of the site, or of the host serving the feed
+	site := resolveURL(feedURL, feed.Channel.Link)
+	if site == "" {
+		site = feedURL
+	}
+	add(resolveURL(site, "/favicon.ico"))
+
+	return candidates
+}
+
+// FetchIcon downloads the first candidate URL that serves an image
+func (c *Client) FetchIcon(ctx context.Context, candidates []string) (*Icon, error) {
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("no icon candidates")
+	}
+
+	var lastErr error
+	for _, candidate := range candidates {
+		icon, err := c.fetchIcon(ctx, candidate)
+		if err == nil {
+			return icon, nil
+		}
+		lastErr = err
+	}
+	return nil, lastErr
+}
+
+// fetchIcon downloads a single icon URL and checks it is an image
+func (c *Client) fetchIcon(ctx context.Context, iconURL string) (*Icon, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", iconURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", "gator")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch icon %s: %w", iconURL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code for icon %s: %d", iconURL, resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxIconSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read icon %s: %w", iconURL, err)
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("icon %s is empty", iconURL)
+	}
+	if len(data) > maxIconSize {
+		return nil, fmt.Errorf("icon %s is larger than %d bytes", iconURL, maxIconSize)
+	}
+
+	// Trust the server's content type when it claims an image, otherwise sniff it
+	contentType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if !strings.HasPrefix(contentType, "image/") {
+		contentType, _, _ = mime.ParseMediaType(http.DetectContentType(data))
+	}
+	if !strings.HasPrefix(contentType, "image/") {
+		return nil, fmt.Errorf("icon %s is not an image (%s)", iconURL, contentType)
+	}
+
+	return &Icon{
+		URL:         iconURL,
+		ContentType: contentType,
+		Data:        data,
+	}, nil
+}
+
+// resolveURL resolves ref against base, returning an empty string if either is invalid
+func resolveURL(base, ref string) string {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return ""
+	}
+	baseURL, err := url.Parse(strings.TrimSpace(base))
+	if err != nil {
+		return ""
+	}
+	refURL, err := url.Parse(ref)
+	if err != nil {
+		return ""
+	}
+	resolved := baseURL.ResolveReference(refURL)
+	if resolved.Scheme != "http" && resolved.Scheme != "https" {
+		return ""
+	}
+	return resolved.String()
+}
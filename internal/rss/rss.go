@@ -2,33 +2,94 @@ package rss
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/xml"
 	"fmt"
 	"html"
 	"io"
 	"net/http"
+	"sort"
+	"strings"
 )
 
 // RSSFeed represents the structure of an RSS feed
 type RSSFeed struct {
 	Channel struct {
-		Title       string    `xml:"title"`
-		Link        string    `xml:"link"`
-		Description string    `xml:"description"`
-		Item        []RSSItem `xml:"item"`
+		Title string `xml:"title"`
+		// AtomLinks captures <atom:link> elements so they don't overwrite Link
+		AtomLinks   []string `xml:"http://www.w3.org/2005/Atom link"`
+		Link        string   `xml:"link"`
+		Description string   `xml:"description"`
+		Language    string   `xml:"language"`
+		Image       RSSImage `xml:"image"`
+		// Icon is only populated from the <icon> element of Atom feeds
+		Icon string    `xml:"-"`
+		Item []RSSItem `xml:"item"`
 	} `xml:"channel"`
 }
 
+// RSSImage represents the image associated with an RSS channel
+type RSSImage struct {
+	URL   string `xml:"url"`
+	Title string `xml:"title"`
+	Link  string `xml:"link"`
+}
+
 // RSSItem represents a single item in an RSS feed
 type RSSItem struct {
 	Title       string `xml:"title"`
 	Link        string `xml:"link"`
 	Description string `xml:"description"`
 	PubDate     string `xml:"pubDate"`
+	// Content holds the full body from <content:encoded> when the feed provides it
+	Content string `xml:"http://purl.org/rss/1.0/modules/content/ encoded"`
+	Author  string `xml:"author"`
+	// Creator holds <dc:creator>, which many feeds use instead of <author>
+	Creator    string   `xml:"http://purl.org/dc/elements/1.1/ creator"`
+	Categories []string `xml:"category"`
+	GUID       string   `xml:"guid"`
+}
+
+// Key returns the identity of the item within its feed: its GUID, or its link without one
+func (item RSSItem) Key() string {
+	if guid := strings.TrimSpace(item.GUID); guid != "" {
+		return guid
+	}
+	return strings.TrimSpace(item.Link)
+}
+
+// AuthorName returns the item's author, preferring <dc:creator> over <author>
+func (item RSSItem) AuthorName() string {
+	if creator := strings.TrimSpace(item.Creator); creator != "" {
+		return creator
+	}
+	return strings.TrimSpace(item.Author)
+}
+
+// Fetcher downloads feeds and their icons. Commands fetch through it so they can be
+// pointed at an httptest server, or given canned feeds, instead of the network.
+type Fetcher interface {
+	FetchFeed(ctx context.Context, feedURL string) (*RSSFeed, error)
+	FetchIcon(ctx context.Context, candidates []string) (*Icon, error)
 }
 
-// fetchFeed fetches an RSS feed from the given URL and returns a parsed RSSFeed struct
-func FetchFeed(ctx context.Context, feedURL string) (*RSSFeed, error) {
+// Client is the Fetcher that downloads over HTTP
+type Client struct {
+	httpClient *http.Client
+}
+
+// NewClient returns a Client making requests with httpClient, or with a default
+// client when it is nil
+func NewClient(httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = &http.Client{}
+	}
+	return &Client{httpClient: httpClient}
+}
+
+// FetchFeed fetches an RSS feed from the given URL and returns a parsed RSSFeed struct
+func (c *Client) FetchFeed(ctx context.Context, feedURL string) (*RSSFeed, error) {
 	// Create HTTP request with context
 	req, err := http.NewRequestWithContext(ctx, "GET", feedURL, nil)
 	if err != nil {
@@ -38,9 +99,8 @@ func FetchFeed(ctx context.Context, feedURL string) (*RSSFeed, error) {
 	// Set User-Agent header
 	req.Header.Set("User-Agent", "gator")
 
-	// Create HTTP client and make request
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	// Make the request
+	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch feed: %w", err)
 	}
@@ -57,9 +117,13 @@ func FetchFeed(ctx context.Context, feedURL string) (*RSSFeed, error) {
 		return nil, fmt.Errorf("failed to read response body: %w", err)
 	}
 
-	// Parse XML into RSSFeed struct
+	// Parse XML into RSSFeed struct, converting Atom feeds to the same shape
 	var feed RSSFeed
-	if err := xml.Unmarshal(body, &feed); err != nil {
+	if isAtomFeed(body) {
+		if err := parseAtomFeed(body, &feed); err != nil {
+			return nil, fmt.Errorf("failed to parse Atom XML: %w", err)
+		}
+	} else if err := xml.Unmarshal(body, &feed); err != nil {
 		return nil, fmt.Errorf("failed to parse XML: %w", err)
 	}
 
@@ -69,6 +133,24 @@ func FetchFeed(ctx context.Context, feedURL string) (*RSSFeed, error) {
 	return &feed, nil
 }
 
+// ContentHash fingerprints the items of a feed so that feeds served from different URLs
+// with identical content can be detected. It returns an empty string for feeds without items.
+func ContentHash(feed *RSSFeed) string {
+	var links []string
+	for _, item := range feed.Channel.Item {
+		if link := strings.TrimSpace(item.Link); link != "" {
+			links = append(links, link)
+		}
+	}
+	if len(links) == 0 {
+		return ""
+	}
+
+	sort.Strings(links)
+	sum := sha256.Sum256([]byte(strings.Join(links, "\n")))
+	return hex.EncodeToString(sum[:])
+}
+
 // decodeHTML decodes HTML entities in the RSS feed text fields
 func decodeHTML(feed *RSSFeed) {
 	// Decode channel fields
@@ -0,0 +1,176 @@
+package rules
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Fields that a rule can match against
+const (
+	FieldTitle       = "title"
+	FieldDescription = "description"
+	FieldAuthor      = "author"
+	FieldCategory    = "category"
+	FieldFeed        = "feed"
+)
+
+// Ways a rule's pattern can be matched
+const (
+	MatchSubstring = "substring"
+	MatchRegex     = "regex"
+	MatchGlob      = "glob"
+)
+
+// Actions a rule can take on a matching post
+const (
+	ActionHide     = "hide"
+	ActionMarkRead = "mark-read"
+	ActionStar     = "star"
+	ActionTag      = "tag"
+)
+
+var (
+	fields  = []string{FieldTitle, FieldDescription, FieldAuthor, FieldCategory, FieldFeed}
+	matches = []string{MatchSubstring, MatchRegex, MatchGlob}
+	actions = []string{ActionHide, ActionMarkRead, ActionStar, ActionTag}
+)
+
+// Post holds the parts of a post that rules can match against
+type Post struct {
+	Title       string
+	Description string
+	Author      string
+	Categories  []string
+	Feed        string
+}
+
+// Matcher tests a single field of a post against a pattern. All matching is case-insensitive.
+type Matcher struct {
+	Field   string
+	Match   string
+	Pattern string
+	re      *regexp.Regexp
+}
+
+// NewMatcher validates the field and match type and compiles the pattern
+func NewMatcher(field, match, pattern string) (*Matcher, error) {
+	if !contains(fields, field) {
+		return nil, fmt.Errorf("invalid field '%s', use one of: %s", field, strings.Join(fields, ", "))
+	}
+	if !contains(matches, match) {
+		return nil, fmt.Errorf("invalid match type '%s', use one of: %s", match, strings.Join(matches, ", "))
+	}
+	if pattern == "" {
+		return nil, fmt.Errorf("pattern cannot be empty")
+	}
+
+	m := &Matcher{Field: field, Match: match, Pattern: pattern}
+	switch match {
+	case MatchRegex:
+		re, err := regexp.Compile("(?i)" + pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid regex: %w", err)
+		}
+		m.re = re
+	case MatchGlob:
+		m.re = regexp.MustCompile("(?is)^" + globToRegex(pattern) + "$")
+	}
+	return m, nil
+}
+
+// ValidateAction checks the action and that a tag is given exactly when the action needs one
+func ValidateAction(action, tag string) error {
+	if !contains(actions, action) {
+		return fmt.Errorf("invalid action '%s', use one of: %s", action, strings.Join(actions, ", "))
+	}
+	if action == ActionTag && tag == "" {
+		return fmt.Errorf("the tag action needs a tag name")
+	}
+	if action != ActionTag && tag != "" {
+		return fmt.Errorf("only the tag action takes a tag name")
+	}
+	return nil
+}
+
+// Matches reports whether the post matches. A category rule matches if any category does.
+func (m *Matcher) Matches(post Post) bool {
+	switch m.Field {
+	case FieldTitle:
+		return m.matchValue(post.Title)
+	case FieldDescription:
+		return m.matchValue(post.Description)
+	case FieldAuthor:
+		return m.matchValue(post.Author)
+	case FieldFeed:
+		return m.matchValue(post.Feed)
+	case FieldCategory:
+		for _, category := range post.Categories {
+			if m.matchValue(category) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// matchValue matches a single value against the pattern
+func (m *Matcher) matchValue(value string) bool {
+	if value == "" {
+		return false
+	}
+	if m.re != nil {
+		return m.re.MatchString(value)
+	}
+	return strings.Contains(strings.ToLower(value), strings.ToLower(m.Pattern))
+}
+
+// globToRegex translates a glob, where * matches any run of characters and ? a single one
+func globToRegex(glob string) string {
+	var b strings.Builder
+	for _, r := range glob {
+		switch r {
+		case '*':
+			b.WriteString(".*")
+		case '?':
+			b.WriteString(".")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	return b.String()
+}
+
+// contains reports whether the list holds the value
+func contains(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
+
+// SplitCategories splits categories as stored on a post, one per line
+func SplitCategories(categories string) []string {
+	var list []string
+	for _, category := range strings.Split(categories, "\n") {
+		if category = strings.TrimSpace(category); category != "" {
+			list = append(list, category)
+		}
+	}
+	return list
+}
+
+// JoinCategories joins categories for storage on a post, one per line
+func JoinCategories(categories []string) string {
+	var list []string
+	for _, category := range categories {
+		// Categories can't span lines once joined
+		category = strings.Join(strings.Fields(category), " ")
+		if category != "" {
+			list = append(list, category)
+		}
+	}
+	return strings.Join(list, "\n")
+}
\ No newline at end of file
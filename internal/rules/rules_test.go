@@ -0,0 +1,127 @@
+package rules
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMatches(t *testing.T) {
+	post := Post{
+		Title:       "Gator 1.2 Released",
+		Description: "Release notes for the new version",
+		Author:      "Jane Doe",
+		Categories:  []string{"Go", "release-notes"},
+		Feed:        "Project News",
+	}
+
+	tests := []struct {
+		name    string
+		field   string
+		match   string
+		pattern string
+		want    bool
+	}{
+		{"substring", FieldTitle, MatchSubstring, "released", true},
+		{"substring case", FieldTitle, MatchSubstring, "GATOR", true},
+		{"substring miss", FieldTitle, MatchSubstring, "postgres", false},
+		{"substring is literal", FieldTitle, MatchSubstring, "1.2*", false},
+		{"regex", FieldTitle, MatchRegex, `\d+\.\d+`, true},
+		{"regex case", FieldTitle, MatchRegex, `^gator\b`, true},
+		{"regex word boundary", FieldTitle, MatchRegex, `\bgat\b`, false},
+		{"regex unanchored", FieldDescription, MatchRegex, "new vers", true},
+		{"glob whole value", FieldAuthor, MatchGlob, "jane*", true},
+		{"glob anchored", FieldAuthor, MatchGlob, "doe*", false},
+		{"glob single character", FieldAuthor, MatchGlob, "J?ne Doe", true},
+		{"glob metacharacters literal", FieldTitle, MatchGlob, "gator 1x2*", false},
+		{"glob dot literal", FieldTitle, MatchGlob, "gator 1.2 *", true},
+		{"category any", FieldCategory, MatchSubstring, "go", true},
+		{"category glob", FieldCategory, MatchGlob, "release-*", true},
+		{"category glob whole category", FieldCategory, MatchGlob, "notes", false},
+		{"category regex", FieldCategory, MatchRegex, "^GO$", true},
+		{"feed", FieldFeed, MatchSubstring, "project", true},
+		{"feed miss", FieldFeed, MatchGlob, "news", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := NewMatcher(tt.field, tt.match, tt.pattern)
+			if err != nil {
+				t.Fatalf("NewMatcher returned error: %v", err)
+			}
+			if got := m.Matches(post); got != tt.want {
+				t.Errorf("%s %s %q matched %v, want %v", tt.field, tt.match, tt.pattern, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchesEmptyValues(t *testing.T) {
+	// Even patterns that match anything need a value to match
+	for _, field := range fields {
+		m, err := NewMatcher(field, MatchGlob, "*")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if m.Matches(Post{}) {
+			t.Errorf("%s rule matched a post without a %s", field, field)
+		}
+	}
+}
+
+func TestNewMatcherErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		field   string
+		match   string
+		pattern string
+	}{
+		{"unknown field", "content", MatchSubstring, "x"},
+		{"unknown match type", FieldTitle, "exact", "x"},
+		{"empty pattern", FieldTitle, MatchSubstring, ""},
+		{"invalid regex", FieldTitle, MatchRegex, "(unclosed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewMatcher(tt.field, tt.match, tt.pattern); err == nil {
+				t.Error("NewMatcher succeeded, want an error")
+			}
+		})
+	}
+}
+
+func TestValidateAction(t *testing.T) {
+	tests := []struct {
+		action  string
+		tag     string
+		wantErr bool
+	}{
+		{ActionHide, "", false},
+		{ActionMarkRead, "", false},
+		{ActionStar, "", false},
+		{ActionTag, "mentions", false},
+		{ActionTag, "", true},
+		{ActionStar, "mentions", true},
+		{"delete", "", true},
+	}
+	for _, tt := range tests {
+		err := ValidateAction(tt.action, tt.tag)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ValidateAction(%q, %q) = %v, want error %v", tt.action, tt.tag, err, tt.wantErr)
+		}
+	}
+}
+
+func TestCategories(t *testing.T) {
+	joined := JoinCategories([]string{" Go ", "", "multi\nline  name", "release-notes"})
+	if want := "Go\nmulti line name\nrelease-notes"; joined != want {
+		t.Errorf("JoinCategories = %q, want %q", joined, want)
+	}
+
+	got := SplitCategories(joined + "\n\n")
+	want := []string{"Go", "multi line name", "release-notes"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SplitCategories = %q, want %q", got, want)
+	}
+	if got := SplitCategories(""); got != nil {
+		t.Errorf("SplitCategories(\"\") = %q, want none", got)
+	}
+}
@@ -0,0 +1,32 @@
+// Package memstore provides a migrated store.Store held in memory, so commands can be
+// run end to end without a database server
+package memstore
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/PassZ/rss-aggregator/internal/migrate"
+	"github.com/PassZ/rss-aggregator/internal/store"
+)
+
+// New returns an empty in-memory store with the full schema applied. It runs the same
+// SQLite queries as a sqlite:// database, so commands behave as they do on disk.
+// Close the store to free its memory.
+func New(ctx context.Context) (store.Store, error) {
+	st, err := store.OpenMemory()
+	if err != nil {
+		return nil, err
+	}
+
+	provider, err := migrate.NewProvider(st)
+	if err != nil {
+		st.Close()
+		return nil, err
+	}
+	if _, err := provider.Up(ctx); err != nil {
+		st.Close()
+		return nil, fmt.Errorf("failed to migrate in-memory database: %w", err)
+	}
+	return st, nil
+}
@@ -0,0 +1,57 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/PassZ/rss-aggregator/internal/database"
+)
+
+// postgresStore runs the sqlc queries generated for Postgres as they are
+type postgresStore struct {
+	*database.Queries
+	db *sql.DB
+}
+
+// postgresTx is a transaction on a postgresStore
+type postgresTx struct {
+	*database.Queries
+	tx *sql.Tx
+}
+
+func openPostgres(dbURL string) (*postgresStore, error) {
+	db, err := sql.Open("postgres", dbURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	return &postgresStore{Queries: database.New(db), db: db}, nil
+}
+
+func (s *postgresStore) BeginTx(ctx context.Context, opts *sql.TxOptions) (Tx, error) {
+	tx, err := s.db.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &postgresTx{Queries: s.Queries.WithTx(tx), tx: tx}, nil
+}
+
+func (s *postgresStore) Driver() string {
+	return DriverPostgres
+}
+
+func (s *postgresStore) DB() *sql.DB {
+	return s.db
+}
+
+func (s *postgresStore) Close() error {
+	return s.db.Close()
+}
+
+func (t *postgresTx) Commit() error {
+	return t.tx.Commit()
+}
+
+func (t *postgresTx) Rollback() error {
+	return t.tx.Rollback()
+}
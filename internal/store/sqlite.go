@@ -0,0 +1,306 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+	"github.com/PassZ/rss-aggregator/internal/database"
+	"github.com/PassZ/rss-aggregator/internal/database/sqlite"
+	_ "modernc.org/sqlite"
+)
+
+// sqliteStore runs the sqlc queries generated for SQLite, converting their types to
+// the Postgres ones
+type sqliteStore struct {
+	sqliteQueries
+	db *sql.DB
+}
+
+// sqliteTx is a transaction on a sqliteStore
+type sqliteTx struct {
+	sqliteQueries
+	tx *sql.Tx
+}
+
+// sqliteQueries implements database.Querier on top of a connection or transaction
+type sqliteQueries struct {
+	q  *sqlite.Queries
+	db sqlite.DBTX
+}
+
+func newSQLiteQueries(db sqlite.DBTX) sqliteQueries {
+	conn := utcConn{db}
+	return sqliteQueries{q: sqlite.New(conn), db: conn}
+}
+
+func openSQLite(path string) (*sqliteStore, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, fmt.Errorf("failed to create database directory: %w", err)
+	}
+	return openSQLiteURI(path, url.Values{})
+}
+
+// OpenMemory opens an empty SQLite database held in memory, for running commands
+// without a database server. Each call gets a database of its own that is gone once
+// the store is closed. It has no tables until it is migrated, which memstore.New does.
+func OpenMemory() (Store, error) {
+	return openSQLiteURI("/gator-"+uuid.NewString(), url.Values{"vfs": {"memdb"}})
+}
+
+// openSQLiteURI opens the SQLite database at a file URI path with gator's settings
+// added to query
+func openSQLiteURI(path string, query url.Values) (*sqliteStore, error) {
+	// Foreign keys are off by default in SQLite, and WAL with a busy timeout lets agg
+	// fetch while other commands read. Write transactions take their lock up front so
+	// they wait for each other instead of failing halfway.
+	query.Add("_pragma", "foreign_keys(1)")
+	query.Add("_pragma", "busy_timeout(5000)")
+	query.Add("_pragma", "journal_mode(WAL)")
+	query.Set("_time_format", "sqlite")
+	query.Set("_txlock", "immediate")
+	db, err := sql.Open("sqlite", "file:"+path+"?"+query.Encode())
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	return &sqliteStore{sqliteQueries: newSQLiteQueries(db), db: db}, nil
+}
+
+// BeginTx starts a transaction. SQLite transactions are always serializable, so the
+// isolation level and read-only flag in opts are not needed and ignored.
+func (s *sqliteStore) BeginTx(ctx context.Context, opts *sql.TxOptions) (Tx, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &sqliteTx{sqliteQueries: newSQLiteQueries(tx), tx: tx}, nil
+}
+
+func (s *sqliteStore) Driver() string {
+	return DriverSQLite
+}
+
+func (s *sqliteStore) DB() *sql.DB {
+	return s.db
+}
+
+func (s *sqliteStore) Close() error {
+	return s.db.Close()
+}
+
+func (t *sqliteTx) Commit() error {
+	return t.tx.Commit()
+}
+
+func (t *sqliteTx) Rollback() error {
+	return t.tx.Rollback()
+}
+
+// utcConn stores every time in UTC. SQLite keeps timestamps as text and compares them
+// as text, which only sorts chronologically when they are all in the same timezone.
+type utcConn struct {
+	sqlite.DBTX
+}
+
+func (c utcConn) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return c.DBTX.ExecContext(ctx, query, utcArgs(args)...)
+}
+
+func (c utcConn) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return c.DBTX.QueryContext(ctx, query, utcArgs(args)...)
+}
+
+func (c utcConn) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return c.DBTX.QueryRowContext(ctx, query, utcArgs(args)...)
+}
+
+func utcArgs(args []interface{}) []interface{} {
+	converted := make([]interface{}, len(args))
+	for i, arg := range args {
+		switch value := arg.(type) {
+		case time.Time:
+			converted[i] = value.UTC()
+		case sql.NullTime:
+			converted[i] = sql.NullTime{Time: value.Time.UTC(), Valid: value.Valid}
+		default:
+			converted[i] = arg
+		}
+	}
+	return converted
+}
+
+// sqliteTimeFormats are the layouts SQLite hands back timestamps in when it can't tell a
+// column holds one: the driver's own, and CURRENT_TIMESTAMP's
+var sqliteTimeFormats = []string{
+	"2006-01-02 15:04:05.999999999-07:00",
+	"2006-01-02 15:04:05",
+}
+
+// parseSQLiteTime reads a timestamp computed by a query rather than read from a column
+func parseSQLiteTime(value interface{}) (time.Time, error) {
+	switch value := value.(type) {
+	case time.Time:
+		return value, nil
+	case string:
+		for _, format := range sqliteTimeFormats {
+			if t, err := time.Parse(format, value); err == nil {
+				return t, nil
+			}
+		}
+		return time.Time{}, fmt.Errorf("invalid timestamp '%s'", value)
+	default:
+		return time.Time{}, fmt.Errorf("invalid timestamp %v", value)
+	}
+}
+
+// searchPosts is SearchPosts for SQLite. It is not generated by sqlc, which can't parse
+// FTS5's MATCH operator. bm25 is lower for better matches, so it is negated to rank
+// like Postgres does, and weighs title, description and content like the Postgres index.
+// Feeds are shown under the user's custom title when they set one, like GetPostsForUser does.
+const searchPosts = `
+SELECT
+    p.id,
+    p.title,
+    p.url,
+    p.published_at,
+    p.created_at,
+    p.feed_id,
+    COALESCE(ff.custom_title, f.name),
+    -bm25(posts_fts, 10.0, 4.0, 1.0),
+    snippet(posts_fts, -1, '**', '**', '...', 20)
+FROM posts_fts
+JOIN posts p ON p.rowid = posts_fts.rowid
+JOIN feeds f ON p.feed_id = f.id
+LEFT JOIN feed_follows ff ON ff.feed_id = p.feed_id AND ff.user_id = ?3
+WHERE posts_fts MATCH ?1
+  AND (?2 OR ff.id IS NOT NULL)
+  AND (?4 IS NULL OR p.feed_id = ?4)
+  AND (?5 IS NULL OR COALESCE(p.published_at, p.created_at) >= ?5)
+ORDER BY bm25(posts_fts, 10.0, 4.0, 1.0), COALESCE(p.published_at, p.created_at) DESC
+LIMIT ?6
+`
+
+func (s *sqliteQueries) SearchPosts(ctx context.Context, arg database.SearchPostsParams) ([]database.SearchPostsRow, error) {
+	query := ftsQuery(arg.Query)
+	if query == "" {
+		return nil, nil
+	}
+
+	rows, err := s.db.QueryContext(ctx, searchPosts, query, arg.AllFeeds, arg.UserID, arg.FeedID, arg.Since, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []database.SearchPostsRow
+	for rows.Next() {
+		var i database.SearchPostsRow
+		var rank float64
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Url,
+			&i.PublishedAt,
+			&i.CreatedAt,
+			&i.FeedID,
+			&i.FeedName,
+			&rank,
+			&i.Snippet,
+		); err != nil {
+			return nil, err
+		}
+		i.Rank = float32(rank)
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// ftsQuery turns a web-style search, as understood by Postgres' websearch_to_tsquery,
+// into an FTS5 query: words must all match, "quoted text" matches as a phrase, "or"
+// between two terms matches either, and -word excludes posts containing it.
+// Terms are quoted so punctuation in them is never read as FTS5 syntax. It returns ""
+// when nothing is left to search for.
+func ftsQuery(search string) string {
+	var groups [][]string
+	var excluded []string
+	or := false
+
+	for _, term := range splitSearch(search) {
+		negate := strings.HasPrefix(term, "-") && len(term) > 1
+		if negate {
+			term = term[1:]
+		}
+		if !strings.HasPrefix(term, `"`) && strings.EqualFold(term, "or") {
+			or = len(groups) > 0
+			continue
+		}
+
+		text := strings.Trim(term, `"`)
+		if strings.IndexFunc(text, func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) }) < 0 {
+			continue
+		}
+		quoted := `"` + strings.ReplaceAll(text, `"`, `""`) + `"`
+
+		switch {
+		case negate:
+			excluded = append(excluded, quoted)
+		case or:
+			groups[len(groups)-1] = append(groups[len(groups)-1], quoted)
+		default:
+			groups = append(groups, []string{quoted})
+		}
+		or = false
+	}
+
+	if len(groups) == 0 {
+		return ""
+	}
+	parts := make([]string, len(groups))
+	for i, group := range groups {
+		parts[i] = strings.Join(group, " OR ")
+		if len(group) > 1 {
+			parts[i] = "(" + parts[i] + ")"
+		}
+	}
+	query := strings.Join(parts, " AND ")
+	for _, term := range excluded {
+		query += " NOT " + term
+	}
+	return query
+}
+
+// splitSearch splits a search on whitespace, keeping "quoted text" together
+func splitSearch(search string) []string {
+	var terms []string
+	var current strings.Builder
+	quoted := false
+
+	for _, r := range search {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			current.WriteRune(r)
+		case unicode.IsSpace(r) && !quoted:
+			if current.Len() > 0 {
+				terms = append(terms, current.String())
+				current.Reset()
+			}
+		default:
+			current.WriteRune(r)
+		}
+	}
+	if current.Len() > 0 {
+		terms = append(terms, current.String())
+	}
+	return terms
+}
@@ -0,0 +1,552 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/PassZ/rss-aggregator/internal/database"
+	"github.com/PassZ/rss-aggregator/internal/database/sqlite"
+)
+
+// Most SQLite queries return the same rows as their Postgres counterparts and convert
+// directly. The ones below differ where SQLite can't infer a type or has no such column.
+
+// convertAll converts every row of a query result
+func convertAll[From, To any](rows []From, convert func(From) To) []To {
+	if rows == nil {
+		return nil
+	}
+	converted := make([]To, len(rows))
+	for i, row := range rows {
+		converted[i] = convert(row)
+	}
+	return converted
+}
+
+// postFromSQLite converts a post, which has no search vector in SQLite
+func postFromSQLite(post sqlite.Post) database.Post {
+	return database.Post{
+		ID:          post.ID,
+		CreatedAt:   post.CreatedAt,
+		UpdatedAt:   post.UpdatedAt,
+		Title:       post.Title,
+		Url:         post.Url,
+		Description: post.Description,
+		PublishedAt: post.PublishedAt,
+		FeedID:      post.FeedID,
+		Content:     post.Content,
+		Author:      post.Author,
+		Categories:  post.Categories,
+		Guid:        post.Guid,
+	}
+}
+
+func (s *sqliteQueries) CreateFeedFollow(ctx context.Context, arg database.CreateFeedFollowParams) (database.CreateFeedFollowRow, error) {
+	row, err := s.q.CreateFeedFollow(ctx, sqlite.CreateFeedFollowParams(arg))
+	return database.CreateFeedFollowRow{
+		ID:        row.ID,
+		CreatedAt: row.CreatedAt,
+		UpdatedAt: row.UpdatedAt,
+		UserID:    row.UserID,
+		FeedID:    row.FeedID,
+		UserName:  row.Name,
+		FeedName:  row.Name_2,
+	}, err
+}
+
+func (s *sqliteQueries) CreatePost(ctx context.Context, arg database.CreatePostParams) (database.Post, error) {
+	post, err := s.q.CreatePost(ctx, sqlite.CreatePostParams(arg))
+	return postFromSQLite(post), err
+}
+
+func (s *sqliteQueries) GetAllPosts(ctx context.Context) ([]database.GetAllPostsRow, error) {
+	rows, err := s.q.GetAllPosts(ctx)
+	return convertAll(rows, func(row sqlite.Post) database.GetAllPostsRow { return database.GetAllPostsRow(row) }), err
+}
+
+func (s *sqliteQueries) GetPostsForUser(ctx context.Context, arg database.GetPostsForUserParams) ([]database.GetPostsForUserRow, error) {
+	rows, err := s.q.GetPostsForUser(ctx, sqlite.GetPostsForUserParams{
+		SortBy:      arg.SortBy,
+		UserID:      arg.UserID,
+		UnreadOnly:  arg.UnreadOnly,
+		FeedID:      arg.FeedID,
+		Tag:         arg.Tag,
+		Folder:      arg.Folder,
+		Since:       arg.Since,
+		Until:       arg.Until,
+		AfterSortAt: arg.AfterSortAt,
+		AfterID:     arg.AfterID,
+		Offset:      int64(arg.Offset),
+		Limit:       int64(arg.Limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]database.GetPostsForUserRow, len(rows))
+	for i, row := range rows {
+		// The sort time is computed, so it comes back as text
+		sortAt, err := parseSQLiteTime(row.SortAt)
+		if err != nil {
+			return nil, err
+		}
+		items[i] = database.GetPostsForUserRow{
+			ID:          row.ID,
+			CreatedAt:   row.CreatedAt,
+			UpdatedAt:   row.UpdatedAt,
+			Title:       row.Title,
+			Url:         row.Url,
+			Description: row.Description,
+			PublishedAt: row.PublishedAt,
+			FeedID:      row.FeedID,
+			FeedName:    row.FeedName,
+			ReadAt:      row.ReadAt,
+			StarredAt:   row.StarredAt,
+			Tags:        row.Tags,
+			SortAt:      sortAt,
+		}
+	}
+	return items, nil
+}
+
+func (s *sqliteQueries) GetPrunablePosts(ctx context.Context, arg database.GetPrunablePostsParams) ([]database.GetPrunablePostsRow, error) {
+	rows, err := s.q.GetPrunablePosts(ctx, sqlite.GetPrunablePostsParams{
+		DefaultMaxAgeDays: sql.NullInt32{Int32: arg.DefaultMaxAgeDays, Valid: true},
+		Now:               arg.Now,
+		DefaultMaxPosts:   sql.NullInt32{Int32: arg.DefaultMaxPosts, Valid: true},
+	})
+	return convertAll(rows, func(row sqlite.GetPrunablePostsRow) database.GetPrunablePostsRow {
+		return database.GetPrunablePostsRow(row)
+	}), err
+}
+
+func (s *sqliteQueries) MarkPostsRead(ctx context.Context, arg database.MarkPostsReadParams) (int64, error) {
+	return s.q.MarkPostsRead(ctx, sqlite.MarkPostsReadParams{
+		ReadAt: arg.ReadAt,
+		UserID: arg.UserID,
+		PostID: arg.PostID,
+		FeedID: arg.FeedID,
+		Before: arg.Before,
+	})
+}
+
+func (s *sqliteQueries) AddFeedFollowTag(ctx context.Context, arg database.AddFeedFollowTagParams) error {
+	return s.q.AddFeedFollowTag(ctx, sqlite.AddFeedFollowTagParams(arg))
+}
+
+func (s *sqliteQueries) CountAdmins(ctx context.Context) (int64, error) {
+	return s.q.CountAdmins(ctx)
+}
+
+func (s *sqliteQueries) CreateFeed(ctx context.Context, arg database.CreateFeedParams) (database.Feed, error) {
+	feed, err := s.q.CreateFeed(ctx, sqlite.CreateFeedParams(arg))
+	return database.Feed(feed), err
+}
+
+func (s *sqliteQueries) CreatePostTombstone(ctx context.Context, arg database.CreatePostTombstoneParams) error {
+	return s.q.CreatePostTombstone(ctx, sqlite.CreatePostTombstoneParams(arg))
+}
+
+func (s *sqliteQueries) CreateRule(ctx context.Context, arg database.CreateRuleParams) (database.Rule, error) {
+	rule, err := s.q.CreateRule(ctx, sqlite.CreateRuleParams(arg))
+	return database.Rule(rule), err
+}
+
+func (s *sqliteQueries) CreateSession(ctx context.Context, arg database.CreateSessionParams) (database.Session, error) {
+	session, err := s.q.CreateSession(ctx, sqlite.CreateSessionParams(arg))
+	return database.Session(session), err
+}
+
+func (s *sqliteQueries) CreateUser(ctx context.Context, arg database.CreateUserParams) (database.User, error) {
+	user, err := s.q.CreateUser(ctx, sqlite.CreateUserParams(arg))
+	return database.User(user), err
+}
+
+func (s *sqliteQueries) DeleteAllFeeds(ctx context.Context) error {
+	return s.q.DeleteAllFeeds(ctx)
+}
+
+func (s *sqliteQueries) DeleteAllPosts(ctx context.Context) error {
+	return s.q.DeleteAllPosts(ctx)
+}
+
+func (s *sqliteQueries) DeleteAllUsers(ctx context.Context) error {
+	return s.q.DeleteAllUsers(ctx)
+}
+
+func (s *sqliteQueries) DeleteExpiredSessions(ctx context.Context, expiresAt time.Time) error {
+	return s.q.DeleteExpiredSessions(ctx, expiresAt)
+}
+
+func (s *sqliteQueries) DeleteFeed(ctx context.Context, id uuid.UUID) error {
+	return s.q.DeleteFeed(ctx, id)
+}
+
+func (s *sqliteQueries) DeleteFeedFollow(ctx context.Context, arg database.DeleteFeedFollowParams) error {
+	return s.q.DeleteFeedFollow(ctx, sqlite.DeleteFeedFollowParams(arg))
+}
+
+func (s *sqliteQueries) DeletePost(ctx context.Context, id uuid.UUID) error {
+	return s.q.DeletePost(ctx, id)
+}
+
+func (s *sqliteQueries) DeleteRule(ctx context.Context, arg database.DeleteRuleParams) (int64, error) {
+	return s.q.DeleteRule(ctx, sqlite.DeleteRuleParams(arg))
+}
+
+func (s *sqliteQueries) DeleteSession(ctx context.Context, tokenHash string) error {
+	return s.q.DeleteSession(ctx, tokenHash)
+}
+
+func (s *sqliteQueries) DeleteUser(ctx context.Context, id uuid.UUID) error {
+	return s.q.DeleteUser(ctx, id)
+}
+
+func (s *sqliteQueries) DeleteUserFeedFollows(ctx context.Context, userID uuid.UUID) error {
+	return s.q.DeleteUserFeedFollows(ctx, userID)
+}
+
+func (s *sqliteQueries) DeleteUserPostReads(ctx context.Context, userID uuid.UUID) error {
+	return s.q.DeleteUserPostReads(ctx, userID)
+}
+
+func (s *sqliteQueries) DeleteUserPostStars(ctx context.Context, userID uuid.UUID) error {
+	return s.q.DeleteUserPostStars(ctx, userID)
+}
+
+func (s *sqliteQueries) DeleteUserPostTags(ctx context.Context, userID uuid.UUID) error {
+	return s.q.DeleteUserPostTags(ctx, userID)
+}
+
+func (s *sqliteQueries) DeleteUserRules(ctx context.Context, userID uuid.UUID) error {
+	return s.q.DeleteUserRules(ctx, userID)
+}
+
+func (s *sqliteQueries) DeleteUserSessions(ctx context.Context, userID uuid.UUID) error {
+	return s.q.DeleteUserSessions(ctx, userID)
+}
+
+func (s *sqliteQueries) FindRestoredFeed(ctx context.Context, arg database.FindRestoredFeedParams) (uuid.UUID, error) {
+	return s.q.FindRestoredFeed(ctx, sqlite.FindRestoredFeedParams(arg))
+}
+
+func (s *sqliteQueries) FindRestoredFeedFollow(ctx context.Context, arg database.FindRestoredFeedFollowParams) (uuid.UUID, error) {
+	return s.q.FindRestoredFeedFollow(ctx, sqlite.FindRestoredFeedFollowParams(arg))
+}
+
+func (s *sqliteQueries) FindRestoredPost(ctx context.Context, arg database.FindRestoredPostParams) (uuid.UUID, error) {
+	return s.q.FindRestoredPost(ctx, sqlite.FindRestoredPostParams(arg))
+}
+
+func (s *sqliteQueries) FindRestoredUser(ctx context.Context, arg database.FindRestoredUserParams) (uuid.UUID, error) {
+	return s.q.FindRestoredUser(ctx, sqlite.FindRestoredUserParams(arg))
+}
+
+func (s *sqliteQueries) GetAllFeedFollowTags(ctx context.Context) ([]database.FeedFollowTag, error) {
+	rows, err := s.q.GetAllFeedFollowTags(ctx)
+	return convertAll(rows, func(row sqlite.FeedFollowTag) database.FeedFollowTag { return database.FeedFollowTag(row) }), err
+}
+
+func (s *sqliteQueries) GetAllFeedFollows(ctx context.Context) ([]database.FeedFollow, error) {
+	rows, err := s.q.GetAllFeedFollows(ctx)
+	return convertAll(rows, func(row sqlite.FeedFollow) database.FeedFollow { return database.FeedFollow(row) }), err
+}
+
+func (s *sqliteQueries) GetAllFeeds(ctx context.Context) ([]database.Feed, error) {
+	rows, err := s.q.GetAllFeeds(ctx)
+	return convertAll(rows, func(row sqlite.Feed) database.Feed { return database.Feed(row) }), err
+}
+
+func (s *sqliteQueries) GetAllPostHides(ctx context.Context) ([]database.PostHide, error) {
+	rows, err := s.q.GetAllPostHides(ctx)
+	return convertAll(rows, func(row sqlite.PostHide) database.PostHide { return database.PostHide(row) }), err
+}
+
+func (s *sqliteQueries) GetAllPostReads(ctx context.Context) ([]database.PostRead, error) {
+	rows, err := s.q.GetAllPostReads(ctx)
+	return convertAll(rows, func(row sqlite.PostRead) database.PostRead { return database.PostRead(row) }), err
+}
+
+func (s *sqliteQueries) GetAllPostStars(ctx context.Context) ([]database.PostStar, error) {
+	rows, err := s.q.GetAllPostStars(ctx)
+	return convertAll(rows, func(row sqlite.PostStar) database.PostStar { return database.PostStar(row) }), err
+}
+
+func (s *sqliteQueries) GetAllPostTags(ctx context.Context) ([]database.PostTag, error) {
+	rows, err := s.q.GetAllPostTags(ctx)
+	return convertAll(rows, func(row sqlite.PostTag) database.PostTag { return database.PostTag(row) }), err
+}
+
+func (s *sqliteQueries) GetAllPostTombstones(ctx context.Context) ([]database.PostTombstone, error) {
+	rows, err := s.q.GetAllPostTombstones(ctx)
+	return convertAll(rows, func(row sqlite.PostTombstone) database.PostTombstone { return database.PostTombstone(row) }), err
+}
+
+func (s *sqliteQueries) GetAllRules(ctx context.Context) ([]database.Rule, error) {
+	rows, err := s.q.GetAllRules(ctx)
+	return convertAll(rows, func(row sqlite.Rule) database.Rule { return database.Rule(row) }), err
+}
+
+func (s *sqliteQueries) GetFeedByURL(ctx context.Context, url string) (database.Feed, error) {
+	feed, err := s.q.GetFeedByURL(ctx, url)
+	return database.Feed(feed), err
+}
+
+func (s *sqliteQueries) GetFeedByURLKey(ctx context.Context, urlKey sql.NullString) (database.Feed, error) {
+	feed, err := s.q.GetFeedByURLKey(ctx, urlKey)
+	return database.Feed(feed), err
+}
+
+func (s *sqliteQueries) GetFeedFollow(ctx context.Context, arg database.GetFeedFollowParams) (database.FeedFollow, error) {
+	follow, err := s.q.GetFeedFollow(ctx, sqlite.GetFeedFollowParams(arg))
+	return database.FeedFollow(follow), err
+}
+
+func (s *sqliteQueries) GetFeedFollowTagsForUser(ctx context.Context, userID uuid.UUID) ([]database.GetFeedFollowTagsForUserRow, error) {
+	rows, err := s.q.GetFeedFollowTagsForUser(ctx, userID)
+	return convertAll(rows, func(row sqlite.GetFeedFollowTagsForUserRow) database.GetFeedFollowTagsForUserRow {
+		return database.GetFeedFollowTagsForUserRow(row)
+	}), err
+}
+
+func (s *sqliteQueries) GetFeedFollowersToNotify(ctx context.Context, feedID uuid.UUID) ([]database.GetFeedFollowersToNotifyRow, error) {
+	rows, err := s.q.GetFeedFollowersToNotify(ctx, feedID)
+	return convertAll(rows, func(row sqlite.GetFeedFollowersToNotifyRow) database.GetFeedFollowersToNotifyRow {
+		return database.GetFeedFollowersToNotifyRow(row)
+	}), err
+}
+
+func (s *sqliteQueries) GetFeedFollowsForUser(ctx context.Context, userID uuid.UUID) ([]database.GetFeedFollowsForUserRow, error) {
+	rows, err := s.q.GetFeedFollowsForUser(ctx, userID)
+	return convertAll(rows, func(row sqlite.GetFeedFollowsForUserRow) database.GetFeedFollowsForUserRow {
+		return database.GetFeedFollowsForUserRow(row)
+	}), err
+}
+
+func (s *sqliteQueries) GetFeedIcon(ctx context.Context, feedID uuid.UUID) (database.FeedIcon, error) {
+	icon, err := s.q.GetFeedIcon(ctx, feedID)
+	return database.FeedIcon(icon), err
+}
+
+func (s *sqliteQueries) GetFeedStats(ctx context.Context, feedID uuid.UUID) (database.GetFeedStatsRow, error) {
+	stats, err := s.q.GetFeedStats(ctx, feedID)
+	return database.GetFeedStatsRow(stats), err
+}
+
+func (s *sqliteQueries) GetFeeds(ctx context.Context) ([]database.GetFeedsRow, error) {
+	rows, err := s.q.GetFeeds(ctx)
+	return convertAll(rows, func(row sqlite.GetFeedsRow) database.GetFeedsRow { return database.GetFeedsRow(row) }), err
+}
+
+func (s *sqliteQueries) GetNextFeedToFetch(ctx context.Context) (database.Feed, error) {
+	feed, err := s.q.GetNextFeedToFetch(ctx)
+	return database.Feed(feed), err
+}
+
+func (s *sqliteQueries) GetPostsForRules(ctx context.Context, userID uuid.UUID) ([]database.GetPostsForRulesRow, error) {
+	rows, err := s.q.GetPostsForRules(ctx, userID)
+	return convertAll(rows, func(row sqlite.GetPostsForRulesRow) database.GetPostsForRulesRow {
+		return database.GetPostsForRulesRow(row)
+	}), err
+}
+
+func (s *sqliteQueries) GetRulesForFeed(ctx context.Context, feedID uuid.UUID) ([]database.GetRulesForFeedRow, error) {
+	rows, err := s.q.GetRulesForFeed(ctx, feedID)
+	return convertAll(rows, func(row sqlite.GetRulesForFeedRow) database.GetRulesForFeedRow {
+		return database.GetRulesForFeedRow{Rule: database.Rule(row.Rule), FeedName: row.FeedName}
+	}), err
+}
+
+func (s *sqliteQueries) GetRulesForUser(ctx context.Context, userID uuid.UUID) ([]database.Rule, error) {
+	rows, err := s.q.GetRulesForUser(ctx, userID)
+	return convertAll(rows, func(row sqlite.Rule) database.Rule { return database.Rule(row) }), err
+}
+
+func (s *sqliteQueries) GetSessionUser(ctx context.Context, arg database.GetSessionUserParams) (database.GetSessionUserRow, error) {
+	row, err := s.q.GetSessionUser(ctx, sqlite.GetSessionUserParams(arg))
+	return database.GetSessionUserRow{User: database.User(row.User), LastUsedAt: row.LastUsedAt}, err
+}
+
+func (s *sqliteQueries) GetStarredPosts(ctx context.Context, userID uuid.UUID) ([]database.PostStar, error) {
+	rows, err := s.q.GetStarredPosts(ctx, userID)
+	return convertAll(rows, func(row sqlite.PostStar) database.PostStar { return database.PostStar(row) }), err
+}
+
+func (s *sqliteQueries) GetTagsForUser(ctx context.Context, userID uuid.UUID) ([]database.GetTagsForUserRow, error) {
+	rows, err := s.q.GetTagsForUser(ctx, userID)
+	return convertAll(rows, func(row sqlite.GetTagsForUserRow) database.GetTagsForUserRow { return database.GetTagsForUserRow(row) }), err
+}
+
+func (s *sqliteQueries) GetUser(ctx context.Context, name string) (database.User, error) {
+	user, err := s.q.GetUser(ctx, name)
+	return database.User(user), err
+}
+
+func (s *sqliteQueries) GetUserStats(ctx context.Context, userID uuid.UUID) (database.GetUserStatsRow, error) {
+	stats, err := s.q.GetUserStats(ctx, userID)
+	return database.GetUserStatsRow(stats), err
+}
+
+func (s *sqliteQueries) GetUsers(ctx context.Context) ([]database.User, error) {
+	rows, err := s.q.GetUsers(ctx)
+	return convertAll(rows, func(row sqlite.User) database.User { return database.User(row) }), err
+}
+
+func (s *sqliteQueries) HidePost(ctx context.Context, arg database.HidePostParams) error {
+	return s.q.HidePost(ctx, sqlite.HidePostParams(arg))
+}
+
+func (s *sqliteQueries) IsPostTombstoned(ctx context.Context, arg database.IsPostTombstonedParams) (bool, error) {
+	return s.q.IsPostTombstoned(ctx, sqlite.IsPostTombstonedParams(arg))
+}
+
+func (s *sqliteQueries) MarkFeedFetched(ctx context.Context, id uuid.UUID) error {
+	return s.q.MarkFeedFetched(ctx, id)
+}
+
+func (s *sqliteQueries) MarkFeedIconFetched(ctx context.Context, arg database.MarkFeedIconFetchedParams) error {
+	return s.q.MarkFeedIconFetched(ctx, sqlite.MarkFeedIconFetchedParams(arg))
+}
+
+func (s *sqliteQueries) MoveFeedFollowTags(ctx context.Context, arg database.MoveFeedFollowTagsParams) error {
+	return s.q.MoveFeedFollowTags(ctx, sqlite.MoveFeedFollowTagsParams(arg))
+}
+
+func (s *sqliteQueries) MoveFeedFollows(ctx context.Context, arg database.MoveFeedFollowsParams) (int64, error) {
+	return s.q.MoveFeedFollows(ctx, sqlite.MoveFeedFollowsParams(arg))
+}
+
+func (s *sqliteQueries) MovePostTombstones(ctx context.Context, arg database.MovePostTombstonesParams) error {
+	return s.q.MovePostTombstones(ctx, sqlite.MovePostTombstonesParams(arg))
+}
+
+func (s *sqliteQueries) MovePosts(ctx context.Context, arg database.MovePostsParams) (int64, error) {
+	return s.q.MovePosts(ctx, sqlite.MovePostsParams(arg))
+}
+
+func (s *sqliteQueries) RemoveFeedFollowTag(ctx context.Context, arg database.RemoveFeedFollowTagParams) (int64, error) {
+	return s.q.RemoveFeedFollowTag(ctx, sqlite.RemoveFeedFollowTagParams(arg))
+}
+
+func (s *sqliteQueries) RenameUser(ctx context.Context, arg database.RenameUserParams) (database.User, error) {
+	user, err := s.q.RenameUser(ctx, sqlite.RenameUserParams(arg))
+	return database.User(user), err
+}
+
+func (s *sqliteQueries) RestoreFeed(ctx context.Context, arg database.RestoreFeedParams) (uuid.UUID, error) {
+	return s.q.RestoreFeed(ctx, sqlite.RestoreFeedParams(arg))
+}
+
+func (s *sqliteQueries) RestoreFeedFollow(ctx context.Context, arg database.RestoreFeedFollowParams) (uuid.UUID, error) {
+	return s.q.RestoreFeedFollow(ctx, sqlite.RestoreFeedFollowParams(arg))
+}
+
+func (s *sqliteQueries) RestoreFeedFollowTag(ctx context.Context, arg database.RestoreFeedFollowTagParams) (int64, error) {
+	return s.q.RestoreFeedFollowTag(ctx, sqlite.RestoreFeedFollowTagParams(arg))
+}
+
+func (s *sqliteQueries) RestorePost(ctx context.Context, arg database.RestorePostParams) (uuid.UUID, error) {
+	return s.q.RestorePost(ctx, sqlite.RestorePostParams(arg))
+}
+
+func (s *sqliteQueries) RestorePostHide(ctx context.Context, arg database.RestorePostHideParams) (int64, error) {
+	return s.q.RestorePostHide(ctx, sqlite.RestorePostHideParams(arg))
+}
+
+func (s *sqliteQueries) RestorePostRead(ctx context.Context, arg database.RestorePostReadParams) (int64, error) {
+	return s.q.RestorePostRead(ctx, sqlite.RestorePostReadParams(arg))
+}
+
+func (s *sqliteQueries) RestorePostStar(ctx context.Context, arg database.RestorePostStarParams) (int64, error) {
+	return s.q.RestorePostStar(ctx, sqlite.RestorePostStarParams(arg))
+}
+
+func (s *sqliteQueries) RestorePostTag(ctx context.Context, arg database.RestorePostTagParams) (int64, error) {
+	return s.q.RestorePostTag(ctx, sqlite.RestorePostTagParams(arg))
+}
+
+func (s *sqliteQueries) RestorePostTombstone(ctx context.Context, arg database.RestorePostTombstoneParams) (int64, error) {
+	return s.q.RestorePostTombstone(ctx, sqlite.RestorePostTombstoneParams(arg))
+}
+
+func (s *sqliteQueries) RestoreRule(ctx context.Context, arg database.RestoreRuleParams) (int64, error) {
+	return s.q.RestoreRule(ctx, sqlite.RestoreRuleParams(arg))
+}
+
+func (s *sqliteQueries) RestoreUser(ctx context.Context, arg database.RestoreUserParams) (uuid.UUID, error) {
+	return s.q.RestoreUser(ctx, sqlite.RestoreUserParams(arg))
+}
+
+func (s *sqliteQueries) SetFeedContentHash(ctx context.Context, arg database.SetFeedContentHashParams) error {
+	return s.q.SetFeedContentHash(ctx, sqlite.SetFeedContentHashParams(arg))
+}
+
+func (s *sqliteQueries) SetFeedRetention(ctx context.Context, arg database.SetFeedRetentionParams) (database.Feed, error) {
+	feed, err := s.q.SetFeedRetention(ctx, sqlite.SetFeedRetentionParams(arg))
+	return database.Feed(feed), err
+}
+
+func (s *sqliteQueries) SetFeedURLKey(ctx context.Context, arg database.SetFeedURLKeyParams) error {
+	return s.q.SetFeedURLKey(ctx, sqlite.SetFeedURLKeyParams(arg))
+}
+
+func (s *sqliteQueries) SetUserPassword(ctx context.Context, arg database.SetUserPasswordParams) error {
+	return s.q.SetUserPassword(ctx, sqlite.SetUserPasswordParams(arg))
+}
+
+func (s *sqliteQueries) SetUserRole(ctx context.Context, arg database.SetUserRoleParams) (database.User, error) {
+	user, err := s.q.SetUserRole(ctx, sqlite.SetUserRoleParams(arg))
+	return database.User(user), err
+}
+
+func (s *sqliteQueries) SetUserTimezone(ctx context.Context, arg database.SetUserTimezoneParams) (database.User, error) {
+	user, err := s.q.SetUserTimezone(ctx, sqlite.SetUserTimezoneParams(arg))
+	return database.User(user), err
+}
+
+func (s *sqliteQueries) StarPost(ctx context.Context, arg database.StarPostParams) (database.PostStar, error) {
+	star, err := s.q.StarPost(ctx, sqlite.StarPostParams(arg))
+	return database.PostStar(star), err
+}
+
+func (s *sqliteQueries) TagPost(ctx context.Context, arg database.TagPostParams) error {
+	return s.q.TagPost(ctx, sqlite.TagPostParams(arg))
+}
+
+func (s *sqliteQueries) TouchSession(ctx context.Context, arg database.TouchSessionParams) error {
+	return s.q.TouchSession(ctx, sqlite.TouchSessionParams(arg))
+}
+
+func (s *sqliteQueries) TransferFeed(ctx context.Context, arg database.TransferFeedParams) error {
+	return s.q.TransferFeed(ctx, sqlite.TransferFeedParams(arg))
+}
+
+func (s *sqliteQueries) TransferUserFeeds(ctx context.Context, arg database.TransferUserFeedsParams) (int64, error) {
+	return s.q.TransferUserFeeds(ctx, sqlite.TransferUserFeedsParams(arg))
+}
+
+func (s *sqliteQueries) UnstarPost(ctx context.Context, arg database.UnstarPostParams) (int64, error) {
+	return s.q.UnstarPost(ctx, sqlite.UnstarPostParams(arg))
+}
+
+func (s *sqliteQueries) UpdateFeed(ctx context.Context, arg database.UpdateFeedParams) (database.Feed, error) {
+	feed, err := s.q.UpdateFeed(ctx, sqlite.UpdateFeedParams(arg))
+	return database.Feed(feed), err
+}
+
+func (s *sqliteQueries) UpdateFeedFollowSettings(ctx context.Context, arg database.UpdateFeedFollowSettingsParams) (database.FeedFollow, error) {
+	follow, err := s.q.UpdateFeedFollowSettings(ctx, sqlite.UpdateFeedFollowSettingsParams(arg))
+	return database.FeedFollow(follow), err
+}
+
+func (s *sqliteQueries) UpdateFeedMetadata(ctx context.Context, arg database.UpdateFeedMetadataParams) error {
+	return s.q.UpdateFeedMetadata(ctx, sqlite.UpdateFeedMetadataParams(arg))
+}
+
+func (s *sqliteQueries) UpsertFeedIcon(ctx context.Context, arg database.UpsertFeedIconParams) error {
+	return s.q.UpsertFeedIcon(ctx, sqlite.UpsertFeedIconParams(arg))
+}
+
+var _ database.Querier = (*sqliteQueries)(nil)
@@ -0,0 +1,95 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/lib/pq"
+	"github.com/PassZ/rss-aggregator/internal/database"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+// Storage drivers, as returned by Store.Driver
+const (
+	DriverPostgres = "postgres"
+	DriverSQLite   = "sqlite"
+)
+
+// Store is the storage the CLI works against. Both backends answer the same queries
+// with the same types, so commands don't need to know which one they are talking to.
+type Store interface {
+	database.Querier
+	// BeginTx starts a transaction whose queries are all committed or rolled back together
+	BeginTx(ctx context.Context, opts *sql.TxOptions) (Tx, error)
+	// Driver names the backend, DriverPostgres or DriverSQLite
+	Driver() string
+	// DB returns the connection pool behind the store, for running migrations
+	DB() *sql.DB
+	Close() error
+}
+
+// Tx is a Store transaction
+type Tx interface {
+	database.Querier
+	Commit() error
+	Rollback() error
+}
+
+// Open connects to the database at dbURL, picking the backend from its scheme:
+// postgres:// or postgresql:// for Postgres and sqlite:// followed by a file path for SQLite
+func Open(dbURL string) (Store, error) {
+	scheme, rest, ok := strings.Cut(dbURL, "://")
+	if !ok {
+		return nil, fmt.Errorf("invalid db_url, use postgres://... or sqlite://<path>")
+	}
+
+	switch strings.ToLower(scheme) {
+	case "postgres", "postgresql":
+		return openPostgres(dbURL)
+	case "sqlite", "sqlite3":
+		path, err := expandHome(rest)
+		if err != nil {
+			return nil, err
+		}
+		return openSQLite(path)
+	default:
+		return nil, fmt.Errorf("unsupported database '%s', use postgres://... or sqlite://<path>", scheme)
+	}
+}
+
+// expandHome replaces a leading ~ in a path with the home directory
+func expandHome(path string) (string, error) {
+	if path == "" {
+		return "", fmt.Errorf("invalid db_url, sqlite:// needs a file path")
+	}
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path, nil
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(homeDir, strings.TrimPrefix(path, "~")), nil
+}
+
+// IsUniqueViolation reports whether err is a query failing on a unique constraint,
+// such as saving a post whose URL is already stored
+func IsUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return pqErr.Code == "23505"
+	}
+	var sqliteErr *sqlite.Error
+	if errors.As(err, &sqliteErr) {
+		code := sqliteErr.Code()
+		return code == sqlite3.SQLITE_CONSTRAINT_UNIQUE || code == sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY
+	}
+	return false
+}
@@ -1,63 +1,56 @@
 package main
 
 import (
-	"database/sql"
+	"context"
+	"flag"
 	"fmt"
 	"log"
 	"os"
+	// Embedded zoneinfo so user timezones work on systems without it
+	_ "time/tzdata"
 
-	_ "github.com/lib/pq"
 	"github.com/PassZ/rss-aggregator/internal/cli"
 	"github.com/PassZ/rss-aggregator/internal/config"
-	"github.com/PassZ/rss-aggregator/internal/database"
+	"github.com/PassZ/rss-aggregator/internal/migrate"
+	"github.com/PassZ/rss-aggregator/internal/rss"
+	"github.com/PassZ/rss-aggregator/internal/store"
 )
 
 func main() {
-	// Read the config file
-	cfg, err := config.Read()
-	if err != nil {
-		log.Fatalf("Failed to read config: %v", err)
+	// Global flags come before the command name
+	flags := flag.NewFlagSet("gator", flag.ContinueOnError)
+	configPath := flags.String("config", "", "config file to use instead of the default one")
+	profile := flags.String("profile", "", "config profile to use instead of the current one")
+	if err := flags.Parse(os.Args[1:]); err != nil {
+		os.Exit(2)
 	}
 
-	// Open database connection
-	db, err := sql.Open("postgres", cfg.DbURL)
+	// Read the config file
+	cfg, err := config.Read(*configPath, *profile)
 	if err != nil {
-		log.Fatalf("Failed to open database: %v", err)
+		log.Fatalf("Failed to read config: %v", err)
 	}
-	defer db.Close()
 
-	// Create database queries instance
-	dbQueries := database.New(db)
-
-	// Create state with database and config
+	// Create state with config, the database is opened once the command is known
 	state := &cli.State{
-		DB:     dbQueries,
-		Config: cfg,
+		Config:  cfg,
+		Fetcher: rss.NewClient(nil),
 	}
 
 	// Create commands instance and register handlers
 	commands := cli.NewCommands()
-	commands.Register("login", cli.HandlerLogin)
-	commands.Register("register", cli.HandlerRegister)
-	commands.Register("reset", cli.HandlerReset)
-	commands.Register("users", cli.HandlerUsers)
-	commands.Register("agg", cli.HandlerAgg)
-	commands.Register("addfeed", cli.MiddlewareLoggedIn(cli.HandlerAddFeed))
-	commands.Register("feeds", cli.HandlerFeeds)
-	commands.Register("follow", cli.MiddlewareLoggedIn(cli.HandlerFollow))
-	commands.Register("following", cli.MiddlewareLoggedIn(cli.HandlerFollowing))
-	commands.Register("unfollow", cli.MiddlewareLoggedIn(cli.HandlerUnfollow))
-	commands.Register("browse", cli.MiddlewareLoggedIn(cli.HandlerBrowse))
+	cli.RegisterCommands(commands)
 
 	// Check if enough arguments were provided
-	if len(os.Args) < 2 {
+	args := flags.Args()
+	if len(args) < 1 {
 		fmt.Fprintf(os.Stderr, "Error: not enough arguments provided\n")
 		os.Exit(1)
 	}
 
 	// Parse command line arguments
-	cmdName := os.Args[1]
-	cmdArgs := os.Args[2:]
+	cmdName := args[0]
+	cmdArgs := args[1:]
 
 	// Create command instance
 	cmd := cli.Command{
@@ -65,6 +58,31 @@ func main() {
 		Args: cmdArgs,
 	}
 
+	// Profiles live in the config file alone and init sets up the database itself,
+	// every other command needs the database
+	if cmd.Name != "profile" && cmd.Name != "init" {
+		if err := cfg.Validate(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		// Open the Postgres or SQLite database named by db_url
+		db, err := store.Open(cfg.DbURL)
+		if err != nil {
+			log.Fatalf("Failed to open database: %v", err)
+		}
+		defer db.Close()
+		state.DB = db
+
+		// Refuse to run against an older schema, except to migrate it
+		if cmd.Name != "migrate" {
+			if err := migrate.Check(context.Background(), db); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		}
+	}
+
 	// Run the command
 	if err := commands.Run(state, cmd); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
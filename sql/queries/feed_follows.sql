@@ -23,14 +23,59 @@ SELECT
     ff.updated_at,
     ff.user_id,
     ff.feed_id,
+    ff.custom_title,
+    ff.muted,
+    ff.priority,
+    ff.notify,
     u.name as user_name,
-    f.name as feed_name
+    COALESCE(ff.custom_title, f.name)::text as feed_name,
+    f.url as feed_url,
+    f.link as feed_link,
+    (
+        SELECT COUNT(*) FROM posts p
+        WHERE p.feed_id = ff.feed_id
+          AND NOT EXISTS (
+              SELECT 1 FROM post_reads pr
+              WHERE pr.post_id = p.id AND pr.user_id = ff.user_id
+          )
+          AND NOT EXISTS (
+              SELECT 1 FROM post_hides ph
+              WHERE ph.post_id = p.id AND ph.user_id = ff.user_id
+          )
+    ) as unread_count
 FROM feed_follows ff
 JOIN users u ON ff.user_id = u.id
 JOIN feeds f ON ff.feed_id = f.id
 WHERE ff.user_id = $1
-ORDER BY ff.created_at DESC;
+ORDER BY ff.priority DESC, ff.created_at DESC;
 
 -- name: DeleteFeedFollow :exec
 DELETE FROM feed_follows
-WHERE user_id = $1 AND feed_id = $2;
\ No newline at end of file
+WHERE user_id = $1 AND feed_id = $2;
+
+-- name: GetFeedFollow :one
+SELECT * FROM feed_follows
+WHERE user_id = $1 AND feed_id = $2;
+
+-- name: MoveFeedFollows :execrows
+INSERT INTO feed_follows (id, created_at, updated_at, user_id, feed_id, custom_title, muted, priority, notify)
+SELECT gen_random_uuid(), ff.created_at, NOW(), ff.user_id, sqlc.arg(to_feed_id), ff.custom_title, ff.muted, ff.priority, ff.notify
+FROM feed_follows ff
+WHERE ff.feed_id = sqlc.arg(from_feed_id)
+ON CONFLICT (user_id, feed_id) DO NOTHING;
+
+-- name: UpdateFeedFollowSettings :one
+UPDATE feed_follows
+SET custom_title = $2, muted = $3, priority = $4, notify = $5, updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: GetFeedFollowersToNotify :many
+SELECT
+    u.name as user_name,
+    COALESCE(ff.custom_title, f.name)::text as feed_name
+FROM feed_follows ff
+JOIN users u ON ff.user_id = u.id
+JOIN feeds f ON ff.feed_id = f.id
+WHERE ff.feed_id = $1 AND ff.notify AND NOT ff.muted
+ORDER BY u.name;
\ No newline at end of file
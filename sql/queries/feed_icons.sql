@@ -0,0 +1,28 @@
+-- name: GetFeedIcon :one
+SELECT * FROM feed_icons
+WHERE feed_id = $1;
+
+-- name: UpsertFeedIcon :exec
+INSERT INTO feed_icons (feed_id, created_at, updated_at, fetched_at, url, content_type, sha256, data)
+VALUES (
+    $1,
+    $2,
+    $3,
+    $4,
+    $5,
+    $6,
+    $7,
+    $8
+)
+ON CONFLICT (feed_id) DO UPDATE
+SET updated_at = EXCLUDED.updated_at,
+    fetched_at = EXCLUDED.fetched_at,
+    url = EXCLUDED.url,
+    content_type = EXCLUDED.content_type,
+    sha256 = EXCLUDED.sha256,
+    data = EXCLUDED.data;
+
+-- name: MarkFeedIconFetched :exec
+UPDATE feed_icons
+SET fetched_at = $2
+WHERE feed_id = $1;
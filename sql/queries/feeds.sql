@@ -1,12 +1,13 @@
 -- name: CreateFeed :one
-INSERT INTO feeds (id, created_at, updated_at, name, url, user_id)
+INSERT INTO feeds (id, created_at, updated_at, name, url, user_id, url_key)
 VALUES (
     $1,
     $2,
     $3,
     $4,
     $5,
-    $6
+    $6,
+    $7
 )
 RETURNING *;
 
@@ -18,15 +19,30 @@ SELECT
     f.name,
     f.url,
     f.user_id,
-    u.name as user_name
+    f.last_fetched_at,
+    f.title,
+    f.description,
+    f.link,
+    f.language,
+    f.image_url,
+    f.paused,
+    f.url_key,
+    f.content_hash,
+    u.name as user_name,
+    fi.content_type as icon_content_type
 FROM feeds f
 JOIN users u ON f.user_id = u.id
+LEFT JOIN feed_icons fi ON f.id = fi.feed_id
 ORDER BY f.created_at DESC;
 
 -- name: GetFeedByURL :one
 SELECT * FROM feeds
 WHERE url = $1;
 
+-- name: GetFeedByURLKey :one
+SELECT * FROM feeds
+WHERE url_key = $1;
+
 -- name: MarkFeedFetched :exec
 UPDATE feeds
 SET last_fetched_at = NOW(), updated_at = NOW()
@@ -34,5 +50,52 @@ WHERE id = $1;
 
 -- name: GetNextFeedToFetch :one
 SELECT * FROM feeds
+WHERE NOT paused
 ORDER BY last_fetched_at NULLS FIRST, created_at ASC
-LIMIT 1;
\ No newline at end of file
+LIMIT 1;
+
+-- name: UpdateFeedMetadata :exec
+UPDATE feeds
+SET title = $2, description = $3, link = $4, language = $5, image_url = $6, updated_at = NOW()
+WHERE id = $1;
+
+-- name: UpdateFeed :one
+UPDATE feeds
+SET name = $2, url = $3, url_key = $4, paused = $5, updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: TransferFeed :exec
+UPDATE feeds
+SET user_id = $2, updated_at = NOW()
+WHERE id = $1;
+
+-- name: DeleteFeed :exec
+DELETE FROM feeds
+WHERE id = $1;
+
+-- name: GetFeedStats :one
+SELECT
+    (SELECT COUNT(*) FROM feed_follows WHERE feed_follows.feed_id = $1) as follow_count,
+    (SELECT COUNT(*) FROM posts WHERE posts.feed_id = $1) as post_count;
+
+-- name: SetFeedURLKey :exec
+UPDATE feeds
+SET url_key = $2
+WHERE id = $1;
+
+-- name: SetFeedContentHash :exec
+UPDATE feeds
+SET content_hash = $2
+WHERE id = $1;
+
+-- name: SetFeedRetention :one
+UPDATE feeds
+SET retention_max_age_days = $2, retention_max_posts = $3, updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: TransferUserFeeds :execrows
+UPDATE feeds
+SET user_id = sqlc.arg(to_user_id), updated_at = NOW()
+WHERE user_id = sqlc.arg(from_user_id);
\ No newline at end of file
@@ -0,0 +1,10 @@
+-- name: MarkPostsRead :execrows
+INSERT INTO post_reads (user_id, post_id, read_at)
+SELECT ff.user_id, p.id, sqlc.arg(read_at)
+FROM posts p
+JOIN feed_follows ff ON p.feed_id = ff.feed_id
+WHERE ff.user_id = sqlc.arg(user_id)
+  AND (sqlc.narg(post_id)::uuid IS NULL OR p.id = sqlc.narg(post_id))
+  AND (sqlc.narg(feed_id)::uuid IS NULL OR p.feed_id = sqlc.narg(feed_id))
+  AND (sqlc.narg(before)::timestamptz IS NULL OR COALESCE(p.published_at, p.created_at) < sqlc.narg(before))
+ON CONFLICT (user_id, post_id) DO NOTHING;
@@ -0,0 +1,31 @@
+-- name: StarPost :one
+INSERT INTO post_stars (id, created_at, updated_at, user_id, post_id, post_url, title, description, published_at, feed_name, note)
+SELECT
+    sqlc.arg(id),
+    sqlc.arg(created_at),
+    sqlc.arg(created_at),
+    sqlc.arg(user_id),
+    p.id,
+    p.url,
+    p.title,
+    p.description,
+    p.published_at,
+    f.name,
+    sqlc.narg(note)
+FROM posts p
+JOIN feeds f ON p.feed_id = f.id
+WHERE p.id = sqlc.arg(post_id)
+ON CONFLICT (user_id, post_url) DO UPDATE
+SET note = COALESCE(EXCLUDED.note, post_stars.note),
+    post_id = EXCLUDED.post_id,
+    updated_at = EXCLUDED.updated_at
+RETURNING *;
+
+-- name: UnstarPost :execrows
+DELETE FROM post_stars
+WHERE user_id = $1 AND (id = $2 OR post_id = $2);
+
+-- name: GetStarredPosts :many
+SELECT * FROM post_stars
+WHERE user_id = $1
+ORDER BY created_at DESC;
@@ -0,0 +1,17 @@
+-- name: CreatePostTombstone :exec
+INSERT INTO post_tombstones (feed_id, guid, pruned_at)
+VALUES ($1, $2, $3)
+ON CONFLICT (feed_id, guid) DO NOTHING;
+
+-- name: IsPostTombstoned :one
+SELECT EXISTS (
+    SELECT 1 FROM post_tombstones
+    WHERE feed_id = $1 AND guid = $2
+);
+
+-- name: MovePostTombstones :exec
+INSERT INTO post_tombstones (feed_id, guid, pruned_at)
+SELECT sqlc.arg(to_feed_id), t.guid, t.pruned_at
+FROM post_tombstones t
+WHERE t.feed_id = sqlc.arg(from_feed_id)
+ON CONFLICT (feed_id, guid) DO NOTHING;
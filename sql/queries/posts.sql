@@ -1,5 +1,5 @@
 -- name: CreatePost :one
-INSERT INTO posts (id, created_at, updated_at, title, url, description, published_at, feed_id)
+INSERT INTO posts (id, created_at, updated_at, title, url, description, published_at, feed_id, content, author, categories, guid)
 VALUES (
     $1,
     $2,
@@ -8,24 +8,152 @@ VALUES (
     $5,
     $6,
     $7,
-    $8
+    $8,
+    $9,
+    $10,
+    $11,
+    $12
 )
 RETURNING *;
 
 -- name: GetPostsForUser :many
-SELECT 
+-- Posts are ordered by a sort time (published, falling back to fetched, or fetched) and ID,
+-- so a page can continue strictly after the last (sort_at, id) pair of the previous one.
+WITH timeline AS (
+    SELECT
+        p.id,
+        p.created_at,
+        p.updated_at,
+        p.title,
+        p.url,
+        p.description,
+        p.published_at,
+        p.feed_id,
+        COALESCE(ff.custom_title, f.name)::text as feed_name,
+        pr.read_at,
+        ps.created_at as starred_at,
+        COALESCE((
+            SELECT string_agg(pt.tag, ', ' ORDER BY pt.tag) FROM post_tags pt
+            WHERE pt.post_id = p.id AND pt.user_id = ff.user_id
+        ), '')::text as tags,
+        CASE
+            WHEN sqlc.arg(sort_by)::text = 'fetched' THEN p.created_at
+            ELSE COALESCE(p.published_at, p.created_at)
+        END::timestamptz as sort_at
+    FROM posts p
+    JOIN feeds f ON p.feed_id = f.id
+    JOIN feed_follows ff ON f.id = ff.feed_id
+    LEFT JOIN post_reads pr ON pr.post_id = p.id AND pr.user_id = ff.user_id
+    LEFT JOIN post_stars ps ON ps.post_id = p.id AND ps.user_id = ff.user_id
+    WHERE ff.user_id = sqlc.arg(user_id)
+      AND (NOT sqlc.arg(unread_only)::boolean OR pr.post_id IS NULL)
+      AND (sqlc.narg(feed_id)::uuid IS NULL OR p.feed_id = sqlc.narg(feed_id))
+      -- Muted feeds only show up when asked for by name
+      AND (NOT ff.muted OR sqlc.narg(feed_id)::uuid IS NOT NULL)
+      AND NOT EXISTS (
+          SELECT 1 FROM post_hides ph
+          WHERE ph.post_id = p.id AND ph.user_id = ff.user_id
+      )
+      AND (
+          sqlc.narg(tag)::text IS NULL
+          OR EXISTS (
+              SELECT 1 FROM post_tags pt
+              WHERE pt.post_id = p.id AND pt.user_id = ff.user_id AND pt.tag = sqlc.narg(tag)
+          )
+      )
+      AND (
+          sqlc.narg(folder)::text IS NULL
+          OR EXISTS (
+              SELECT 1 FROM feed_follow_tags t
+              WHERE t.feed_follow_id = ff.id
+                AND (t.tag = sqlc.narg(folder) OR starts_with(t.tag, sqlc.narg(folder) || '/'))
+          )
+      )
+)
+SELECT * FROM timeline
+WHERE (sqlc.narg(since)::timestamptz IS NULL OR sort_at >= sqlc.narg(since))
+  AND (sqlc.narg(until)::timestamptz IS NULL OR sort_at < sqlc.narg(until))
+  AND (
+      sqlc.narg(after_sort_at)::timestamptz IS NULL
+      OR (sort_at, id) < (sqlc.narg(after_sort_at), sqlc.narg(after_id)::uuid)
+  )
+ORDER BY sort_at DESC, id DESC
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
+
+-- name: MovePosts :execrows
+UPDATE posts
+SET feed_id = sqlc.arg(to_feed_id), updated_at = NOW()
+WHERE feed_id = sqlc.arg(from_feed_id);
+
+-- name: SearchPosts :many
+SELECT
     p.id,
-    p.created_at,
-    p.updated_at,
     p.title,
     p.url,
-    p.description,
     p.published_at,
+    p.created_at,
     p.feed_id,
-    f.name as feed_name
+    COALESCE(ff.custom_title, f.name)::text as feed_name,
+    ts_rank(p.search_vector, websearch_to_tsquery('english', sqlc.arg(query))) as rank,
+    ts_headline(
+        'english',
+        coalesce(p.description, '') || ' ' || coalesce(p.content, ''),
+        websearch_to_tsquery('english', sqlc.arg(query)),
+        'StartSel=**, StopSel=**, MaxFragments=2, MaxWords=20, MinWords=8'
+    )::text as snippet
 FROM posts p
 JOIN feeds f ON p.feed_id = f.id
-JOIN feed_follows ff ON f.id = ff.feed_id
-WHERE ff.user_id = $1
-ORDER BY p.published_at DESC NULLS LAST, p.created_at DESC
-LIMIT $2;
\ No newline at end of file
+LEFT JOIN feed_follows ff ON ff.feed_id = p.feed_id AND ff.user_id = sqlc.arg(user_id)
+WHERE p.search_vector @@ websearch_to_tsquery('english', sqlc.arg(query))
+  AND (sqlc.arg(all_feeds)::boolean OR ff.id IS NOT NULL)
+  AND (sqlc.narg(feed_id)::uuid IS NULL OR p.feed_id = sqlc.narg(feed_id))
+  AND (sqlc.narg(since)::timestamptz IS NULL OR COALESCE(p.published_at, p.created_at) >= sqlc.narg(since))
+ORDER BY rank DESC, COALESCE(p.published_at, p.created_at) DESC
+LIMIT sqlc.arg('limit');
+
+-- name: GetPrunablePosts :many
+-- Posts past their feed's retention policy, falling back to the global policy when the feed
+-- has none. A limit of 0 keeps posts forever, and starred posts are never pruned.
+WITH ranked AS (
+    SELECT
+        p.id,
+        p.feed_id,
+        p.title,
+        p.url,
+        p.guid,
+        COALESCE(p.published_at, p.created_at) as posted_at,
+        ROW_NUMBER() OVER (
+            PARTITION BY p.feed_id
+            ORDER BY COALESCE(p.published_at, p.created_at) DESC, p.id DESC
+        ) as position
+    FROM posts p
+)
+SELECT
+    r.id,
+    r.feed_id,
+    r.title,
+    r.url,
+    r.guid,
+    f.name as feed_name
+FROM ranked r
+JOIN feeds f ON r.feed_id = f.id
+WHERE NOT EXISTS (
+    SELECT 1 FROM post_stars ps WHERE ps.post_id = r.id
+)
+  AND (
+      (
+          COALESCE(f.retention_max_age_days, sqlc.arg(default_max_age_days)::int) > 0
+          AND r.posted_at < sqlc.arg(now)::timestamptz
+              - make_interval(days => COALESCE(f.retention_max_age_days, sqlc.arg(default_max_age_days)::int))
+      )
+      OR (
+          COALESCE(f.retention_max_posts, sqlc.arg(default_max_posts)::int) > 0
+          AND r.position > COALESCE(f.retention_max_posts, sqlc.arg(default_max_posts)::int)
+      )
+  )
+ORDER BY f.name, r.position;
+
+-- name: DeletePost :exec
+DELETE FROM posts
+WHERE id = $1;
\ No newline at end of file
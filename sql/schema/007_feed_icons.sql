@@ -0,0 +1,14 @@
+-- +goose Up
+CREATE TABLE feed_icons (
+  feed_id UUID PRIMARY KEY REFERENCES feeds(id) ON DELETE CASCADE,
+  created_at TIMESTAMP NOT NULL,
+  updated_at TIMESTAMP NOT NULL,
+  fetched_at TIMESTAMP NOT NULL,
+  url VARCHAR(1000) NOT NULL,
+  content_type VARCHAR(255) NOT NULL,
+  sha256 VARCHAR(64) NOT NULL,
+  data BYTEA NOT NULL
+);
+
+-- +goose Down
+DROP TABLE feed_icons;
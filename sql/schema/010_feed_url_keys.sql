@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE feeds ADD COLUMN url_key VARCHAR(500) UNIQUE;
+ALTER TABLE feeds ADD COLUMN content_hash VARCHAR(64);
+
+-- +goose Down
+ALTER TABLE feeds DROP COLUMN content_hash;
+ALTER TABLE feeds DROP COLUMN url_key;
@@ -0,0 +1,18 @@
+-- +goose Up
+CREATE TABLE post_stars (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  created_at TIMESTAMP NOT NULL,
+  updated_at TIMESTAMP NOT NULL,
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  post_id UUID REFERENCES posts(id) ON DELETE SET NULL,
+  post_url VARCHAR(1000) NOT NULL,
+  title VARCHAR(500) NOT NULL,
+  description TEXT,
+  published_at TIMESTAMP,
+  feed_name VARCHAR(255) NOT NULL,
+  note TEXT,
+  UNIQUE(user_id, post_url)
+);
+
+-- +goose Down
+DROP TABLE post_stars;
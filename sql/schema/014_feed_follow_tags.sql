@@ -0,0 +1,24 @@
+-- +goose Up
+CREATE TABLE feed_follow_tags (
+  feed_follow_id UUID NOT NULL REFERENCES feed_follows(id) ON DELETE CASCADE,
+  tag VARCHAR(255) NOT NULL,
+  created_at TIMESTAMP NOT NULL,
+  PRIMARY KEY (feed_follow_id, tag)
+);
+
+INSERT INTO feed_follow_tags (feed_follow_id, tag, created_at)
+SELECT id, folder, updated_at FROM feed_follows
+WHERE folder IS NOT NULL;
+
+ALTER TABLE feed_follows DROP COLUMN folder;
+
+-- +goose Down
+ALTER TABLE feed_follows ADD COLUMN folder VARCHAR(255);
+
+UPDATE feed_follows
+SET folder = (
+  SELECT MIN(tag) FROM feed_follow_tags
+  WHERE feed_follow_tags.feed_follow_id = feed_follows.id
+);
+
+DROP TABLE feed_follow_tags;
@@ -0,0 +1,40 @@
+-- +goose Up
+ALTER TABLE posts ADD COLUMN author TEXT;
+-- Categories are stored one per line
+ALTER TABLE posts ADD COLUMN categories TEXT;
+
+CREATE TABLE rules (
+  id UUID PRIMARY KEY,
+  created_at TIMESTAMP NOT NULL,
+  updated_at TIMESTAMP NOT NULL,
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  field VARCHAR(20) NOT NULL,
+  match_type VARCHAR(20) NOT NULL,
+  pattern TEXT NOT NULL,
+  action VARCHAR(20) NOT NULL,
+  tag VARCHAR(255)
+);
+
+CREATE TABLE post_hides (
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  post_id UUID NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+  -- Removing a rule brings back the posts it hid
+  rule_id UUID NOT NULL REFERENCES rules(id) ON DELETE CASCADE,
+  hidden_at TIMESTAMP NOT NULL,
+  PRIMARY KEY (user_id, post_id)
+);
+
+CREATE TABLE post_tags (
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  post_id UUID NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+  tag VARCHAR(255) NOT NULL,
+  created_at TIMESTAMP NOT NULL,
+  PRIMARY KEY (user_id, post_id, tag)
+);
+
+-- +goose Down
+DROP TABLE post_tags;
+DROP TABLE post_hides;
+DROP TABLE rules;
+ALTER TABLE posts DROP COLUMN categories;
+ALTER TABLE posts DROP COLUMN author;
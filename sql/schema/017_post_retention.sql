@@ -0,0 +1,20 @@
+-- +goose Up
+ALTER TABLE posts ADD COLUMN guid TEXT;
+
+-- NULL falls back to the global policy in the config file, 0 keeps posts forever
+ALTER TABLE feeds ADD COLUMN retention_max_age_days INTEGER;
+ALTER TABLE feeds ADD COLUMN retention_max_posts INTEGER;
+
+-- Pruned items are remembered by GUID (or URL) so the next fetch doesn't bring them back
+CREATE TABLE post_tombstones (
+  feed_id UUID NOT NULL REFERENCES feeds(id) ON DELETE CASCADE,
+  guid TEXT NOT NULL,
+  pruned_at TIMESTAMP NOT NULL,
+  PRIMARY KEY (feed_id, guid)
+);
+
+-- +goose Down
+DROP TABLE post_tombstones;
+ALTER TABLE feeds DROP COLUMN retention_max_posts;
+ALTER TABLE feeds DROP COLUMN retention_max_age_days;
+ALTER TABLE posts DROP COLUMN guid;
@@ -0,0 +1,79 @@
+-- +goose Up
+-- Existing values were written in the server's local time, which is how the
+-- session time zone interprets them during the conversion
+ALTER TABLE users
+  ALTER COLUMN created_at TYPE TIMESTAMPTZ,
+  ALTER COLUMN updated_at TYPE TIMESTAMPTZ;
+ALTER TABLE feeds
+  ALTER COLUMN created_at TYPE TIMESTAMPTZ,
+  ALTER COLUMN updated_at TYPE TIMESTAMPTZ,
+  ALTER COLUMN last_fetched_at TYPE TIMESTAMPTZ;
+ALTER TABLE feed_follows
+  ALTER COLUMN created_at TYPE TIMESTAMPTZ,
+  ALTER COLUMN updated_at TYPE TIMESTAMPTZ;
+ALTER TABLE posts
+  ALTER COLUMN created_at TYPE TIMESTAMPTZ,
+  ALTER COLUMN updated_at TYPE TIMESTAMPTZ,
+  ALTER COLUMN published_at TYPE TIMESTAMPTZ;
+ALTER TABLE feed_icons
+  ALTER COLUMN created_at TYPE TIMESTAMPTZ,
+  ALTER COLUMN updated_at TYPE TIMESTAMPTZ,
+  ALTER COLUMN fetched_at TYPE TIMESTAMPTZ;
+ALTER TABLE post_reads
+  ALTER COLUMN read_at TYPE TIMESTAMPTZ;
+ALTER TABLE post_stars
+  ALTER COLUMN created_at TYPE TIMESTAMPTZ,
+  ALTER COLUMN updated_at TYPE TIMESTAMPTZ,
+  ALTER COLUMN published_at TYPE TIMESTAMPTZ;
+ALTER TABLE feed_follow_tags
+  ALTER COLUMN created_at TYPE TIMESTAMPTZ;
+ALTER TABLE rules
+  ALTER COLUMN created_at TYPE TIMESTAMPTZ,
+  ALTER COLUMN updated_at TYPE TIMESTAMPTZ;
+ALTER TABLE post_hides
+  ALTER COLUMN hidden_at TYPE TIMESTAMPTZ;
+ALTER TABLE post_tags
+  ALTER COLUMN created_at TYPE TIMESTAMPTZ;
+ALTER TABLE post_tombstones
+  ALTER COLUMN pruned_at TYPE TIMESTAMPTZ;
+
+ALTER TABLE users ADD COLUMN timezone VARCHAR(64);
+
+-- +goose Down
+ALTER TABLE users DROP COLUMN timezone;
+
+ALTER TABLE users
+  ALTER COLUMN created_at TYPE TIMESTAMP,
+  ALTER COLUMN updated_at TYPE TIMESTAMP;
+ALTER TABLE feeds
+  ALTER COLUMN created_at TYPE TIMESTAMP,
+  ALTER COLUMN updated_at TYPE TIMESTAMP,
+  ALTER COLUMN last_fetched_at TYPE TIMESTAMP;
+ALTER TABLE feed_follows
+  ALTER COLUMN created_at TYPE TIMESTAMP,
+  ALTER COLUMN updated_at TYPE TIMESTAMP;
+ALTER TABLE posts
+  ALTER COLUMN created_at TYPE TIMESTAMP,
+  ALTER COLUMN updated_at TYPE TIMESTAMP,
+  ALTER COLUMN published_at TYPE TIMESTAMP;
+ALTER TABLE feed_icons
+  ALTER COLUMN created_at TYPE TIMESTAMP,
+  ALTER COLUMN updated_at TYPE TIMESTAMP,
+  ALTER COLUMN fetched_at TYPE TIMESTAMP;
+ALTER TABLE post_reads
+  ALTER COLUMN read_at TYPE TIMESTAMP;
+ALTER TABLE post_stars
+  ALTER COLUMN created_at TYPE TIMESTAMP,
+  ALTER COLUMN updated_at TYPE TIMESTAMP,
+  ALTER COLUMN published_at TYPE TIMESTAMP;
+ALTER TABLE feed_follow_tags
+  ALTER COLUMN created_at TYPE TIMESTAMP;
+ALTER TABLE rules
+  ALTER COLUMN created_at TYPE TIMESTAMP,
+  ALTER COLUMN updated_at TYPE TIMESTAMP;
+ALTER TABLE post_hides
+  ALTER COLUMN hidden_at TYPE TIMESTAMP;
+ALTER TABLE post_tags
+  ALTER COLUMN created_at TYPE TIMESTAMP;
+ALTER TABLE post_tombstones
+  ALTER COLUMN pruned_at TYPE TIMESTAMP;
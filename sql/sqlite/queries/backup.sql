@@ -0,0 +1,173 @@
+-- name: GetAllFeeds :many
+SELECT * FROM feeds
+ORDER BY created_at, id;
+
+-- name: GetAllFeedFollows :many
+SELECT * FROM feed_follows
+ORDER BY created_at, id;
+
+-- name: GetAllPosts :many
+SELECT
+    id,
+    created_at,
+    updated_at,
+    title,
+    url,
+    description,
+    published_at,
+    feed_id,
+    content,
+    author,
+    categories,
+    guid
+FROM posts
+ORDER BY created_at, id;
+
+-- name: RestoreUser :one
+-- Restores return no row when the record clashes with an existing one
+INSERT INTO users (id, created_at, updated_at, name, role, timezone, password_hash)
+VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7)
+ON CONFLICT DO NOTHING
+RETURNING id;
+
+-- name: FindRestoredUser :one
+-- The existing user a clashing record stands for: the same ID, or else the same name
+SELECT id FROM users
+WHERE id = ?1 OR name = ?2
+ORDER BY id = ?1 DESC
+LIMIT 1;
+
+-- name: RestoreFeed :one
+INSERT INTO feeds (
+    id, created_at, updated_at, name, url, user_id, last_fetched_at,
+    title, description, link, language, image_url, paused, url_key, content_hash,
+    retention_max_age_days, retention_max_posts
+)
+VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7, ?8, ?9, ?10, ?11, ?12, ?13, ?14, ?15, ?16, ?17)
+ON CONFLICT DO NOTHING
+RETURNING id;
+
+-- name: FindRestoredFeed :one
+SELECT id FROM feeds
+WHERE id = ?1 OR url = ?2 OR url_key = ?3
+ORDER BY id = ?1 DESC
+LIMIT 1;
+
+-- name: RestoreFeedFollow :one
+INSERT INTO feed_follows (id, created_at, updated_at, user_id, feed_id, custom_title, muted, priority, notify)
+VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7, ?8, ?9)
+ON CONFLICT DO NOTHING
+RETURNING id;
+
+-- name: FindRestoredFeedFollow :one
+SELECT id FROM feed_follows
+WHERE id = ?1 OR (user_id = ?2 AND feed_id = ?3)
+ORDER BY id = ?1 DESC
+LIMIT 1;
+
+-- name: RestorePost :one
+INSERT INTO posts (
+    id, created_at, updated_at, title, url, description, published_at, feed_id,
+    content, author, categories, guid
+)
+VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7, ?8, ?9, ?10, ?11, ?12)
+ON CONFLICT DO NOTHING
+RETURNING id;
+
+-- name: FindRestoredPost :one
+SELECT id FROM posts
+WHERE id = ?1 OR url = ?2
+ORDER BY id = ?1 DESC
+LIMIT 1;
+
+-- name: GetAllFeedFollowTags :many
+SELECT * FROM feed_follow_tags
+ORDER BY created_at, feed_follow_id, tag;
+
+-- name: RestoreFeedFollowTag :execrows
+INSERT INTO feed_follow_tags (feed_follow_id, tag, created_at)
+VALUES (?1, ?2, ?3)
+ON CONFLICT DO NOTHING;
+
+-- name: GetAllPostTombstones :many
+SELECT * FROM post_tombstones
+ORDER BY pruned_at, feed_id, guid;
+
+-- name: RestorePostTombstone :execrows
+INSERT INTO post_tombstones (feed_id, guid, pruned_at)
+VALUES (?1, ?2, ?3)
+ON CONFLICT DO NOTHING;
+
+-- name: GetAllRules :many
+SELECT * FROM rules
+ORDER BY created_at, id;
+
+-- name: RestoreRule :execrows
+INSERT INTO rules (id, created_at, updated_at, user_id, field, match_type, pattern, action, tag)
+VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7, ?8, ?9)
+ON CONFLICT DO NOTHING;
+
+-- name: GetAllPostReads :many
+SELECT * FROM post_reads
+ORDER BY read_at, user_id, post_id;
+
+-- name: RestorePostRead :execrows
+INSERT INTO post_reads (user_id, post_id, read_at)
+VALUES (?1, ?2, ?3)
+ON CONFLICT DO NOTHING;
+
+-- name: GetAllPostStars :many
+SELECT * FROM post_stars
+ORDER BY created_at, id;
+
+-- name: RestorePostStar :execrows
+INSERT INTO post_stars (
+    id, created_at, updated_at, user_id, post_id, post_url, title, description,
+    published_at, feed_name, note
+)
+VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7, ?8, ?9, ?10, ?11)
+ON CONFLICT DO NOTHING;
+
+-- name: GetAllPostHides :many
+SELECT * FROM post_hides
+ORDER BY hidden_at, user_id, post_id;
+
+-- name: RestorePostHide :execrows
+INSERT INTO post_hides (user_id, post_id, rule_id, hidden_at)
+VALUES (?1, ?2, ?3, ?4)
+ON CONFLICT DO NOTHING;
+
+-- name: GetAllPostTags :many
+SELECT * FROM post_tags
+ORDER BY created_at, user_id, post_id, tag;
+
+-- name: RestorePostTag :execrows
+INSERT INTO post_tags (user_id, post_id, tag, created_at)
+VALUES (?1, ?2, ?3, ?4)
+ON CONFLICT DO NOTHING;
+
+-- name: DeleteAllFeeds :exec
+DELETE FROM feeds;
+
+-- name: DeleteAllPosts :exec
+DELETE FROM posts;
+
+-- name: DeleteUserFeedFollows :exec
+DELETE FROM feed_follows
+WHERE user_id = ?1;
+
+-- name: DeleteUserPostReads :exec
+DELETE FROM post_reads
+WHERE user_id = ?1;
+
+-- name: DeleteUserPostStars :exec
+DELETE FROM post_stars
+WHERE user_id = ?1;
+
+-- name: DeleteUserRules :exec
+DELETE FROM rules
+WHERE user_id = ?1;
+
+-- name: DeleteUserPostTags :exec
+DELETE FROM post_tags
+WHERE user_id = ?1;
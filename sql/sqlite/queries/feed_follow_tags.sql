@@ -0,0 +1,36 @@
+-- name: AddFeedFollowTag :exec
+INSERT INTO feed_follow_tags (feed_follow_id, tag, created_at)
+VALUES (?1, ?2, ?3)
+ON CONFLICT (feed_follow_id, tag) DO NOTHING;
+
+-- name: RemoveFeedFollowTag :execrows
+DELETE FROM feed_follow_tags
+WHERE feed_follow_id = ?1 AND tag = ?2;
+
+-- name: GetFeedFollowTagsForUser :many
+SELECT
+    ff.feed_id,
+    t.tag
+FROM feed_follow_tags t
+JOIN feed_follows ff ON t.feed_follow_id = ff.id
+WHERE ff.user_id = ?1
+ORDER BY t.tag;
+
+-- name: GetTagsForUser :many
+SELECT
+    t.tag,
+    COUNT(*) AS feed_count
+FROM feed_follow_tags t
+JOIN feed_follows ff ON t.feed_follow_id = ff.id
+WHERE ff.user_id = ?1
+GROUP BY t.tag
+ORDER BY t.tag;
+
+-- name: MoveFeedFollowTags :exec
+INSERT INTO feed_follow_tags (feed_follow_id, tag, created_at)
+SELECT target.id, t.tag, t.created_at
+FROM feed_follow_tags t
+JOIN feed_follows source ON t.feed_follow_id = source.id
+JOIN feed_follows target ON target.user_id = source.user_id AND target.feed_id = sqlc.arg(to_feed_id)
+WHERE source.feed_id = sqlc.arg(from_feed_id)
+ON CONFLICT (feed_follow_id, tag) DO NOTHING;
@@ -0,0 +1,89 @@
+-- name: CreateFeedFollow :one
+-- SQLite has no INSERT inside WITH, so the names are looked up in RETURNING instead
+INSERT INTO feed_follows (id, created_at, updated_at, user_id, feed_id)
+VALUES (?1, ?2, ?3, ?4, ?5)
+RETURNING
+    id,
+    created_at,
+    updated_at,
+    user_id,
+    feed_id,
+    (SELECT u.name FROM users u WHERE u.id = feed_follows.user_id) AS user_name,
+    (SELECT f.name FROM feeds f WHERE f.id = feed_follows.feed_id) AS feed_name;
+
+-- name: GetFeedFollowsForUser :many
+SELECT
+    ff.id,
+    ff.created_at,
+    ff.updated_at,
+    ff.user_id,
+    ff.feed_id,
+    ff.custom_title,
+    ff.muted,
+    ff.priority,
+    ff.notify,
+    u.name AS user_name,
+    CAST(COALESCE(ff.custom_title, f.name) AS TEXT) AS feed_name,
+    f.url AS feed_url,
+    f.link AS feed_link,
+    (
+        SELECT COUNT(*) FROM posts p
+        WHERE p.feed_id = ff.feed_id
+          AND NOT EXISTS (
+              SELECT 1 FROM post_reads pr
+              WHERE pr.post_id = p.id AND pr.user_id = ff.user_id
+          )
+          AND NOT EXISTS (
+              SELECT 1 FROM post_hides ph
+              WHERE ph.post_id = p.id AND ph.user_id = ff.user_id
+          )
+    ) AS unread_count
+FROM feed_follows ff
+JOIN users u ON ff.user_id = u.id
+JOIN feeds f ON ff.feed_id = f.id
+WHERE ff.user_id = ?1
+ORDER BY ff.priority DESC, ff.created_at DESC;
+
+-- name: DeleteFeedFollow :exec
+DELETE FROM feed_follows
+WHERE user_id = ?1 AND feed_id = ?2;
+
+-- name: GetFeedFollow :one
+SELECT * FROM feed_follows
+WHERE user_id = ?1 AND feed_id = ?2;
+
+-- name: MoveFeedFollows :execrows
+-- SQLite has no UUID generator, so a version 4 UUID is assembled from random bytes
+INSERT INTO feed_follows (id, created_at, updated_at, user_id, feed_id, custom_title, muted, priority, notify)
+SELECT
+    lower(
+        hex(randomblob(4)) || '-' || hex(randomblob(2)) || '-4' || substr(hex(randomblob(2)), 2) || '-' ||
+        substr('89ab', 1 + (abs(random()) % 4), 1) || substr(hex(randomblob(2)), 2) || '-' || hex(randomblob(6))
+    ),
+    ff.created_at,
+    CURRENT_TIMESTAMP,
+    ff.user_id,
+    sqlc.arg(to_feed_id),
+    ff.custom_title,
+    ff.muted,
+    ff.priority,
+    ff.notify
+FROM feed_follows ff
+WHERE ff.feed_id = sqlc.arg(from_feed_id)
+ON CONFLICT (user_id, feed_id) DO NOTHING;
+
+-- name: UpdateFeedFollowSettings :one
+UPDATE feed_follows
+SET custom_title = ?2, muted = ?3, priority = ?4, notify = ?5, updated_at = CURRENT_TIMESTAMP
+WHERE id = ?1
+RETURNING *;
+
+-- name: GetFeedFollowersToNotify :many
+SELECT
+    u.name AS user_name,
+    CAST(COALESCE(ff.custom_title, f.name) AS TEXT) AS feed_name
+FROM feed_follows ff
+JOIN users u ON ff.user_id = u.id
+JOIN feeds f ON ff.feed_id = f.id
+WHERE ff.feed_id = ?1 AND ff.notify AND NOT ff.muted
+ORDER BY u.name;
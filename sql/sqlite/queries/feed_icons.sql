@@ -0,0 +1,28 @@
+-- name: GetFeedIcon :one
+SELECT * FROM feed_icons
+WHERE feed_id = ?1;
+
+-- name: UpsertFeedIcon :exec
+INSERT INTO feed_icons (feed_id, created_at, updated_at, fetched_at, url, content_type, sha256, data)
+VALUES (
+    ?1,
+    ?2,
+    ?3,
+    ?4,
+    ?5,
+    ?6,
+    ?7,
+    ?8
+)
+ON CONFLICT (feed_id) DO UPDATE
+SET updated_at = excluded.updated_at,
+    fetched_at = excluded.fetched_at,
+    url = excluded.url,
+    content_type = excluded.content_type,
+    sha256 = excluded.sha256,
+    data = excluded.data;
+
+-- name: MarkFeedIconFetched :exec
+UPDATE feed_icons
+SET fetched_at = ?2
+WHERE feed_id = ?1;
@@ -0,0 +1,4 @@
+-- name: HidePost :exec
+INSERT INTO post_hides (user_id, post_id, rule_id, hidden_at)
+VALUES (?1, ?2, ?3, ?4)
+ON CONFLICT (user_id, post_id) DO NOTHING;
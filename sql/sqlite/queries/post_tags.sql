@@ -0,0 +1,4 @@
+-- name: TagPost :exec
+INSERT INTO post_tags (user_id, post_id, tag, created_at)
+VALUES (?1, ?2, ?3, ?4)
+ON CONFLICT (user_id, post_id, tag) DO NOTHING;
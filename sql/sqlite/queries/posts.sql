@@ -0,0 +1,156 @@
+-- name: CreatePost :one
+INSERT INTO posts (id, created_at, updated_at, title, url, description, published_at, feed_id, content, author, categories, guid)
+VALUES (
+    ?1,
+    ?2,
+    ?3,
+    ?4,
+    ?5,
+    ?6,
+    ?7,
+    ?8,
+    ?9,
+    ?10,
+    ?11,
+    ?12
+)
+RETURNING *;
+
+-- name: GetPostsForUser :many
+-- Posts are ordered by a sort time (published, falling back to fetched, or fetched) and ID,
+-- so a page can continue strictly after the last (sort_at, id) pair of the previous one.
+-- sqlc cannot see through a CTE here, so the sort expression is spelled out where it is used.
+SELECT
+    p.id,
+    p.created_at,
+    p.updated_at,
+    p.title,
+    p.url,
+    p.description,
+    p.published_at,
+    p.feed_id,
+    CAST(COALESCE(ff.custom_title, f.name) AS TEXT) AS feed_name,
+    pr.read_at,
+    ps.created_at AS starred_at,
+    CAST(COALESCE((
+        SELECT group_concat(tag, ', ') FROM (
+            SELECT pt.tag FROM post_tags pt
+            WHERE pt.post_id = p.id AND pt.user_id = ff.user_id
+            ORDER BY pt.tag
+        )
+    ), '') AS TEXT) AS tags,
+    CASE
+        WHEN sqlc.arg(sort_by) = 'fetched' THEN p.created_at
+        ELSE COALESCE(p.published_at, p.created_at)
+    END AS sort_at
+FROM posts p
+JOIN feeds f ON p.feed_id = f.id
+JOIN feed_follows ff ON f.id = ff.feed_id
+LEFT JOIN post_reads pr ON pr.post_id = p.id AND pr.user_id = ff.user_id
+LEFT JOIN post_stars ps ON ps.post_id = p.id AND ps.user_id = ff.user_id
+WHERE ff.user_id = sqlc.arg(user_id)
+  AND (CAST(sqlc.arg(unread_only) AS BOOLEAN) = FALSE OR pr.post_id IS NULL)
+  AND (sqlc.narg(feed_id) IS NULL OR p.feed_id = sqlc.narg(feed_id))
+  -- Muted feeds only show up when asked for by name
+  AND (NOT ff.muted OR sqlc.narg(feed_id) IS NOT NULL)
+  AND NOT EXISTS (
+      SELECT 1 FROM post_hides ph
+      WHERE ph.post_id = p.id AND ph.user_id = ff.user_id
+  )
+  AND (
+      sqlc.narg(tag) IS NULL
+      OR EXISTS (
+          SELECT 1 FROM post_tags pt
+          WHERE pt.post_id = p.id AND pt.user_id = ff.user_id AND pt.tag = sqlc.narg(tag)
+      )
+  )
+  AND (
+      sqlc.narg(folder) IS NULL
+      OR EXISTS (
+          SELECT 1 FROM feed_follow_tags t
+          WHERE t.feed_follow_id = ff.id
+            AND (t.tag = sqlc.narg(folder) OR substr(t.tag, 1, length(sqlc.narg(folder)) + 1) = sqlc.narg(folder) || '/')
+      )
+  )
+  AND (
+      sqlc.narg(since) IS NULL
+      OR CASE
+          WHEN sqlc.arg(sort_by) = 'fetched' THEN p.created_at
+          ELSE COALESCE(p.published_at, p.created_at)
+      END >= sqlc.narg(since)
+  )
+  AND (
+      sqlc.narg(until) IS NULL
+      OR CASE
+          WHEN sqlc.arg(sort_by) = 'fetched' THEN p.created_at
+          ELSE COALESCE(p.published_at, p.created_at)
+      END < sqlc.narg(until)
+  )
+  AND (
+      sqlc.narg(after_sort_at) IS NULL
+      OR CASE
+          WHEN sqlc.arg(sort_by) = 'fetched' THEN p.created_at
+          ELSE COALESCE(p.published_at, p.created_at)
+      END < sqlc.narg(after_sort_at)
+      OR (
+          CASE
+              WHEN sqlc.arg(sort_by) = 'fetched' THEN p.created_at
+              ELSE COALESCE(p.published_at, p.created_at)
+          END = sqlc.narg(after_sort_at)
+          AND p.id < sqlc.narg(after_id)
+      )
+  )
+ORDER BY sort_at DESC, p.id DESC
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
+
+-- name: MovePosts :execrows
+UPDATE posts
+SET feed_id = sqlc.arg(to_feed_id), updated_at = CURRENT_TIMESTAMP
+WHERE feed_id = sqlc.arg(from_feed_id);
+
+-- name: GetPrunablePosts :many
+-- Posts past their feed's retention policy, falling back to the global policy when the feed
+-- has none. A limit of 0 keeps posts forever, and starred posts are never pruned.
+WITH ranked AS (
+    SELECT
+        p.id,
+        p.feed_id,
+        p.title,
+        p.url,
+        p.guid,
+        COALESCE(p.published_at, p.created_at) AS posted_at,
+        ROW_NUMBER() OVER (
+            PARTITION BY p.feed_id
+            ORDER BY COALESCE(p.published_at, p.created_at) DESC, p.id DESC
+        ) AS position
+    FROM posts p
+)
+SELECT
+    r.id,
+    r.feed_id,
+    r.title,
+    r.url,
+    r.guid,
+    f.name AS feed_name
+FROM ranked r
+JOIN feeds f ON r.feed_id = f.id
+WHERE NOT EXISTS (
+    SELECT 1 FROM post_stars ps WHERE ps.post_id = r.id
+)
+  AND (
+      (
+          COALESCE(f.retention_max_age_days, sqlc.arg(default_max_age_days)) > 0
+          AND julianday(r.posted_at) < julianday(sqlc.arg(now))
+              - COALESCE(f.retention_max_age_days, sqlc.arg(default_max_age_days))
+      )
+      OR (
+          COALESCE(f.retention_max_posts, sqlc.arg(default_max_posts)) > 0
+          AND r.position > COALESCE(f.retention_max_posts, sqlc.arg(default_max_posts))
+      )
+  )
+ORDER BY f.name, r.position;
+
+-- name: DeletePost :exec
+DELETE FROM posts
+WHERE id = ?1;
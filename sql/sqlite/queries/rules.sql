@@ -0,0 +1,39 @@
+-- name: CreateRule :one
+INSERT INTO rules (id, created_at, updated_at, user_id, field, match_type, pattern, action, tag)
+VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7, ?8, ?9)
+RETURNING *;
+
+-- name: GetRulesForUser :many
+SELECT * FROM rules
+WHERE user_id = ?1
+ORDER BY created_at;
+
+-- name: GetRulesForFeed :many
+-- Rules of every user following the feed, applied when its posts are fetched, with the
+-- name the user follows the feed under
+SELECT sqlc.embed(r), CAST(COALESCE(ff.custom_title, f.name) AS TEXT) AS feed_name
+FROM rules r
+JOIN feed_follows ff ON r.user_id = ff.user_id
+JOIN feeds f ON ff.feed_id = f.id
+WHERE ff.feed_id = ?1
+ORDER BY r.created_at;
+
+-- name: DeleteRule :execrows
+DELETE FROM rules
+WHERE id = ?1 AND user_id = ?2;
+
+-- name: GetPostsForRules :many
+-- Posts of the feeds a user follows, with the fields rules can match against
+SELECT
+    p.id,
+    p.title,
+    p.description,
+    p.author,
+    p.categories,
+    p.published_at,
+    CAST(COALESCE(ff.custom_title, f.name) AS TEXT) AS feed_name
+FROM posts p
+JOIN feeds f ON p.feed_id = f.id
+JOIN feed_follows ff ON f.id = ff.feed_id
+WHERE ff.user_id = ?1
+ORDER BY COALESCE(p.published_at, p.created_at) DESC;
@@ -0,0 +1,26 @@
+-- name: CreateSession :one
+INSERT INTO sessions (id, created_at, last_used_at, expires_at, user_id, token_hash)
+VALUES (?1, ?2, ?2, ?3, ?4, ?5)
+RETURNING *;
+
+-- name: GetSessionUser :one
+SELECT sqlc.embed(users), sessions.last_used_at FROM sessions
+JOIN users ON sessions.user_id = users.id
+WHERE sessions.token_hash = ?1 AND sessions.expires_at > ?2;
+
+-- name: TouchSession :exec
+UPDATE sessions
+SET last_used_at = ?2
+WHERE token_hash = ?1;
+
+-- name: DeleteSession :exec
+DELETE FROM sessions
+WHERE token_hash = ?1;
+
+-- name: DeleteUserSessions :exec
+DELETE FROM sessions
+WHERE user_id = ?1;
+
+-- name: DeleteExpiredSessions :exec
+DELETE FROM sessions
+WHERE expires_at <= ?1;
@@ -0,0 +1,78 @@
+-- name: CreateUser :one
+-- The first user to register becomes an admin
+INSERT INTO users (id, created_at, updated_at, name, password_hash, role)
+VALUES (
+    ?1,
+    ?2,
+    ?3,
+    ?4,
+    ?5,
+    CASE WHEN EXISTS (SELECT 1 FROM users) THEN 'member' ELSE 'admin' END
+)
+RETURNING *;
+
+-- name: GetUser :one
+SELECT * FROM users
+WHERE name = ?1;
+
+-- name: DeleteAllUsers :exec
+DELETE FROM users;
+
+-- name: GetUsers :many
+SELECT * FROM users
+ORDER BY name;
+
+-- name: SetUserTimezone :one
+UPDATE users
+SET timezone = ?2, updated_at = CURRENT_TIMESTAMP
+WHERE id = ?1
+RETURNING *;
+
+-- name: SetUserPassword :exec
+UPDATE users
+SET password_hash = ?2, updated_at = CURRENT_TIMESTAMP
+WHERE id = ?1;
+
+-- name: SetUserRole :one
+UPDATE users
+SET role = ?2, updated_at = CURRENT_TIMESTAMP
+WHERE id = ?1
+RETURNING *;
+
+-- name: CountAdmins :one
+SELECT COUNT(*) FROM users
+WHERE role = 'admin';
+
+-- name: DeleteUser :exec
+DELETE FROM users
+WHERE id = ?1;
+
+-- name: RenameUser :one
+UPDATE users
+SET name = ?2, updated_at = CURRENT_TIMESTAMP
+WHERE id = ?1
+RETURNING *;
+
+-- name: GetUserStats :one
+SELECT
+    (SELECT COUNT(*) FROM feeds f WHERE f.user_id = sqlc.arg(user_id)) AS feed_count,
+    (SELECT COUNT(*) FROM feed_follows ff WHERE ff.user_id = sqlc.arg(user_id)) AS follow_count,
+    (
+        SELECT COUNT(*) FROM posts p
+        JOIN feed_follows ff ON p.feed_id = ff.feed_id
+        WHERE ff.user_id = sqlc.arg(user_id)
+    ) AS post_count,
+    (
+        SELECT COUNT(*) FROM posts p
+        JOIN feed_follows ff ON p.feed_id = ff.feed_id
+        WHERE ff.user_id = sqlc.arg(user_id)
+          AND NOT EXISTS (
+              SELECT 1 FROM post_reads pr
+              WHERE pr.post_id = p.id AND pr.user_id = ff.user_id
+          )
+          AND NOT EXISTS (
+              SELECT 1 FROM post_hides ph
+              WHERE ph.post_id = p.id AND ph.user_id = ff.user_id
+          )
+    ) AS unread_count,
+    (SELECT COUNT(*) FROM post_stars ps WHERE ps.user_id = sqlc.arg(user_id)) AS star_count;
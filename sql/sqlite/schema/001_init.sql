@@ -0,0 +1,205 @@
+-- +goose Up
+-- The SQLite schema matches the Postgres one after its migration 020. Columns are kept in
+-- the same order so both backends return the same rows. UUIDs are stored as text and
+-- timestamps as UTC text that sorts chronologically.
+CREATE TABLE users (
+  id UUID PRIMARY KEY,
+  created_at TIMESTAMP NOT NULL,
+  updated_at TIMESTAMP NOT NULL,
+  name TEXT UNIQUE NOT NULL,
+  timezone TEXT,
+  -- NULL for users who log in without a password
+  password_hash TEXT,
+  role TEXT NOT NULL DEFAULT 'member'
+    CHECK (role IN ('admin', 'member', 'readonly'))
+);
+
+CREATE TABLE sessions (
+  id UUID PRIMARY KEY,
+  created_at TIMESTAMP NOT NULL,
+  last_used_at TIMESTAMP NOT NULL,
+  expires_at TIMESTAMP NOT NULL,
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  token_hash TEXT UNIQUE NOT NULL
+);
+
+CREATE TABLE feeds (
+  id UUID PRIMARY KEY,
+  created_at TIMESTAMP NOT NULL,
+  updated_at TIMESTAMP NOT NULL,
+  name TEXT NOT NULL,
+  url TEXT UNIQUE NOT NULL,
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  last_fetched_at TIMESTAMP,
+  title TEXT,
+  description TEXT,
+  link TEXT,
+  language TEXT,
+  image_url TEXT,
+  paused BOOLEAN NOT NULL DEFAULT FALSE,
+  url_key TEXT UNIQUE,
+  content_hash TEXT,
+  -- NULL falls back to the global policy in the config file, 0 keeps posts forever
+  retention_max_age_days INTEGER,
+  retention_max_posts INTEGER
+);
+
+CREATE TABLE feed_icons (
+  feed_id UUID PRIMARY KEY REFERENCES feeds(id) ON DELETE CASCADE,
+  created_at TIMESTAMP NOT NULL,
+  updated_at TIMESTAMP NOT NULL,
+  fetched_at TIMESTAMP NOT NULL,
+  url TEXT NOT NULL,
+  content_type TEXT NOT NULL,
+  sha256 TEXT NOT NULL,
+  data BLOB NOT NULL
+);
+
+CREATE TABLE feed_follows (
+  id UUID PRIMARY KEY,
+  created_at TIMESTAMP NOT NULL,
+  updated_at TIMESTAMP NOT NULL,
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  feed_id UUID NOT NULL REFERENCES feeds(id) ON DELETE CASCADE,
+  custom_title TEXT,
+  muted BOOLEAN NOT NULL DEFAULT FALSE,
+  priority INTEGER NOT NULL DEFAULT 0,
+  notify BOOLEAN NOT NULL DEFAULT FALSE,
+  UNIQUE(user_id, feed_id)
+);
+
+CREATE TABLE feed_follow_tags (
+  feed_follow_id UUID NOT NULL REFERENCES feed_follows(id) ON DELETE CASCADE,
+  tag TEXT NOT NULL,
+  created_at TIMESTAMP NOT NULL,
+  PRIMARY KEY (feed_follow_id, tag)
+);
+
+CREATE TABLE posts (
+  id UUID PRIMARY KEY,
+  created_at TIMESTAMP NOT NULL,
+  updated_at TIMESTAMP NOT NULL,
+  title TEXT NOT NULL,
+  url TEXT UNIQUE NOT NULL,
+  description TEXT,
+  published_at TIMESTAMP,
+  feed_id UUID NOT NULL REFERENCES feeds(id) ON DELETE CASCADE,
+  content TEXT,
+  author TEXT,
+  -- Categories are stored one per line
+  categories TEXT,
+  guid TEXT
+);
+
+-- Full-text search over posts, kept in sync by the triggers below. Title matches weigh
+-- more than description matches, which weigh more than content matches. The index refers
+-- to posts by rowid, which VACUUM may renumber, so rebuild it after one with
+-- INSERT INTO posts_fts (posts_fts) VALUES ('rebuild').
+CREATE VIRTUAL TABLE posts_fts USING fts5(
+  title,
+  description,
+  content,
+  content = 'posts',
+  tokenize = 'porter unicode61'
+);
+
+-- +goose StatementBegin
+CREATE TRIGGER posts_fts_insert AFTER INSERT ON posts BEGIN
+  INSERT INTO posts_fts (rowid, title, description, content)
+  VALUES (new.rowid, new.title, new.description, new.content);
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER posts_fts_delete AFTER DELETE ON posts BEGIN
+  INSERT INTO posts_fts (posts_fts, rowid, title, description, content)
+  VALUES ('delete', old.rowid, old.title, old.description, old.content);
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER posts_fts_update AFTER UPDATE ON posts BEGIN
+  INSERT INTO posts_fts (posts_fts, rowid, title, description, content)
+  VALUES ('delete', old.rowid, old.title, old.description, old.content);
+  INSERT INTO posts_fts (rowid, title, description, content)
+  VALUES (new.rowid, new.title, new.description, new.content);
+END;
+-- +goose StatementEnd
+
+CREATE TABLE post_reads (
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  post_id UUID NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+  read_at TIMESTAMP NOT NULL,
+  PRIMARY KEY (user_id, post_id)
+);
+
+CREATE TABLE post_stars (
+  id UUID PRIMARY KEY,
+  created_at TIMESTAMP NOT NULL,
+  updated_at TIMESTAMP NOT NULL,
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  post_id UUID REFERENCES posts(id) ON DELETE SET NULL,
+  post_url TEXT NOT NULL,
+  title TEXT NOT NULL,
+  description TEXT,
+  published_at TIMESTAMP,
+  feed_name TEXT NOT NULL,
+  note TEXT,
+  UNIQUE(user_id, post_url)
+);
+
+CREATE TABLE rules (
+  id UUID PRIMARY KEY,
+  created_at TIMESTAMP NOT NULL,
+  updated_at TIMESTAMP NOT NULL,
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  field TEXT NOT NULL,
+  match_type TEXT NOT NULL,
+  pattern TEXT NOT NULL,
+  action TEXT NOT NULL,
+  tag TEXT
+);
+
+CREATE TABLE post_hides (
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  post_id UUID NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+  -- Removing a rule brings back the posts it hid
+  rule_id UUID NOT NULL REFERENCES rules(id) ON DELETE CASCADE,
+  hidden_at TIMESTAMP NOT NULL,
+  PRIMARY KEY (user_id, post_id)
+);
+
+CREATE TABLE post_tags (
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  post_id UUID NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+  tag TEXT NOT NULL,
+  created_at TIMESTAMP NOT NULL,
+  PRIMARY KEY (user_id, post_id, tag)
+);
+
+-- Pruned items are remembered by GUID (or URL) so the next fetch doesn't bring them back
+CREATE TABLE post_tombstones (
+  feed_id UUID NOT NULL REFERENCES feeds(id) ON DELETE CASCADE,
+  guid TEXT NOT NULL,
+  pruned_at TIMESTAMP NOT NULL,
+  PRIMARY KEY (feed_id, guid)
+);
+
+-- +goose Down
+DROP TABLE post_tombstones;
+DROP TABLE post_tags;
+DROP TABLE post_hides;
+DROP TABLE rules;
+DROP TABLE post_stars;
+DROP TABLE post_reads;
+DROP TRIGGER posts_fts_update;
+DROP TRIGGER posts_fts_delete;
+DROP TRIGGER posts_fts_insert;
+DROP TABLE posts_fts;
+DROP TABLE posts;
+DROP TABLE feed_follow_tags;
+DROP TABLE feed_follows;
+DROP TABLE feed_icons;
+DROP TABLE feeds;
+DROP TABLE sessions;
+DROP TABLE users;